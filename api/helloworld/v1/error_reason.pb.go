@@ -11,6 +11,7 @@ import (
 	sync "sync"
 	unsafe "unsafe"
 
+	_ "github.com/go-kratos/kratos/v2/errors"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 )
@@ -26,7 +27,20 @@ type ErrorReason int32
 
 const (
 	ErrorReason_GREETER_UNSPECIFIED ErrorReason = 0
-	ErrorReason_USER_NOT_FOUND      ErrorReason = 1
+	// Deprecated: The service returns GREETER_NOT_FOUND instead.
+	//
+	// Deprecated: Marked as deprecated in helloworld/v1/error_reason.proto.
+	ErrorReason_USER_NOT_FOUND ErrorReason = 1
+	// The request is malformed or violates a validation rule.
+	ErrorReason_INVALID_ARGUMENT ErrorReason = 2
+	// A resource with the same unique key already exists.
+	ErrorReason_ALREADY_EXISTS ErrorReason = 3
+	// The transaction was aborted by a deadlock or lock contention; retry it.
+	ErrorReason_ABORTED ErrorReason = 4
+	// The resource was modified since the version the request is based on.
+	ErrorReason_VERSION_MISMATCH ErrorReason = 5
+	// The greeter does not exist.
+	ErrorReason_GREETER_NOT_FOUND ErrorReason = 6
 )
 
 // Enum value maps for ErrorReason.
@@ -34,10 +48,20 @@ var (
 	ErrorReason_name = map[int32]string{
 		0: "GREETER_UNSPECIFIED",
 		1: "USER_NOT_FOUND",
+		2: "INVALID_ARGUMENT",
+		3: "ALREADY_EXISTS",
+		4: "ABORTED",
+		5: "VERSION_MISMATCH",
+		6: "GREETER_NOT_FOUND",
 	}
 	ErrorReason_value = map[string]int32{
 		"GREETER_UNSPECIFIED": 0,
 		"USER_NOT_FOUND":      1,
+		"INVALID_ARGUMENT":    2,
+		"ALREADY_EXISTS":      3,
+		"ABORTED":             4,
+		"VERSION_MISMATCH":    5,
+		"GREETER_NOT_FOUND":   6,
 	}
 )
 
@@ -72,10 +96,15 @@ var File_helloworld_v1_error_reason_proto protoreflect.FileDescriptor
 
 const file_helloworld_v1_error_reason_proto_rawDesc = "" +
 	"\n" +
-	" helloworld/v1/error_reason.proto\x12\rhelloworld.v1*:\n" +
+	" helloworld/v1/error_reason.proto\x12\rhelloworld.v1\x1a\x13errors/errors.proto*\xca\x01\n" +
 	"\vErrorReason\x12\x17\n" +
-	"\x13GREETER_UNSPECIFIED\x10\x00\x12\x12\n" +
-	"\x0eUSER_NOT_FOUND\x10\x01B\\\n" +
+	"\x13GREETER_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x0eUSER_NOT_FOUND\x10\x01\x1a\x06\xa8E\x94\x03\b\x01\x12\x1a\n" +
+	"\x10INVALID_ARGUMENT\x10\x02\x1a\x04\xa8E\x90\x03\x12\x18\n" +
+	"\x0eALREADY_EXISTS\x10\x03\x1a\x04\xa8E\x99\x03\x12\x11\n" +
+	"\aABORTED\x10\x04\x1a\x04\xa8E\x99\x03\x12\x1a\n" +
+	"\x10VERSION_MISMATCH\x10\x05\x1a\x04\xa8E\x9c\x03\x12\x1b\n" +
+	"\x11GREETER_NOT_FOUND\x10\x06\x1a\x04\xa8E\x94\x03\x1a\x04\xa0E\xf4\x03B\\\n" +
 	"\rhelloworld.v1P\x01Z7github.com/go-kratos/kratos-layout/api/helloworld/v1;v1\xa2\x02\x0fAPIHelloworldV1b\x06proto3"
 
 var (
@@ -11,9 +11,12 @@ import (
 	sync "sync"
 	unsafe "unsafe"
 
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -113,18 +116,544 @@ func (x *HelloReply) GetMessage() string {
 	return ""
 }
 
+// A greeter.
+type GreeterInfo struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hello      string                 `protobuf:"bytes,2,opt,name=hello,proto3" json:"hello,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// etag changes on every update of the greeter.
+	Etag string `protobuf:"bytes,5,opt,name=etag,proto3" json:"etag,omitempty"`
+	// delete_time is only set on deleted greeters, see include_deleted.
+	DeleteTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	// created_by and updated_by are the principals that created and last
+	// updated the greeter, if known.
+	CreatedBy     string `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy     string `protobuf:"bytes,8,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GreeterInfo) Reset() {
+	*x = GreeterInfo{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GreeterInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GreeterInfo) ProtoMessage() {}
+
+func (x *GreeterInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GreeterInfo.ProtoReflect.Descriptor instead.
+func (*GreeterInfo) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GreeterInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GreeterInfo) GetHello() string {
+	if x != nil {
+		return x.Hello
+	}
+	return ""
+}
+
+func (x *GreeterInfo) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *GreeterInfo) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *GreeterInfo) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *GreeterInfo) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
+func (x *GreeterInfo) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *GreeterInfo) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type CreateGreeterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hello         string                 `protobuf:"bytes,1,opt,name=hello,proto3" json:"hello,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGreeterRequest) Reset() {
+	*x = CreateGreeterRequest{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGreeterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGreeterRequest) ProtoMessage() {}
+
+func (x *CreateGreeterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGreeterRequest.ProtoReflect.Descriptor instead.
+func (*CreateGreeterRequest) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateGreeterRequest) GetHello() string {
+	if x != nil {
+		return x.Hello
+	}
+	return ""
+}
+
+type GetGreeterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGreeterRequest) Reset() {
+	*x = GetGreeterRequest{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGreeterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGreeterRequest) ProtoMessage() {}
+
+func (x *GetGreeterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGreeterRequest.ProtoReflect.Descriptor instead.
+func (*GetGreeterRequest) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetGreeterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdateGreeterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hello string                 `protobuf:"bytes,2,opt,name=hello,proto3" json:"hello,omitempty"`
+	// etag is the etag of the greeter the update is based on. The update fails
+	// when the greeter has changed since. Over HTTP it may be sent as If-Match
+	// instead; when both are empty the update is unconditional.
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGreeterRequest) Reset() {
+	*x = UpdateGreeterRequest{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGreeterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGreeterRequest) ProtoMessage() {}
+
+func (x *UpdateGreeterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGreeterRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGreeterRequest) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateGreeterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateGreeterRequest) GetHello() string {
+	if x != nil {
+		return x.Hello
+	}
+	return ""
+}
+
+func (x *UpdateGreeterRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type DeleteGreeterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGreeterRequest) Reset() {
+	*x = DeleteGreeterRequest{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGreeterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGreeterRequest) ProtoMessage() {}
+
+func (x *DeleteGreeterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGreeterRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGreeterRequest) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteGreeterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RestoreGreeterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreGreeterRequest) Reset() {
+	*x = RestoreGreeterRequest{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreGreeterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreGreeterRequest) ProtoMessage() {}
+
+func (x *RestoreGreeterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreGreeterRequest.ProtoReflect.Descriptor instead.
+func (*RestoreGreeterRequest) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RestoreGreeterRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListGreetersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size defaults to 50 and is at most 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page. The other fields
+	// must not change between pages.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// filter is an AIP-160 filter over id, hello, create_time and update_time,
+	// e.g. `hello = "kratos*" AND create_time >= "2024-01-01T00:00:00Z"`.
+	Filter string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	// order_by is an AIP-132 ordering such as "create_time desc", defaulting to id.
+	OrderBy string `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// include_deleted also lists deleted greeters.
+	IncludeDeleted bool `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListGreetersRequest) Reset() {
+	*x = ListGreetersRequest{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGreetersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGreetersRequest) ProtoMessage() {}
+
+func (x *ListGreetersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGreetersRequest.ProtoReflect.Descriptor instead.
+func (*ListGreetersRequest) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListGreetersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListGreetersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListGreetersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListGreetersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListGreetersRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListGreetersResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Greeters []*GreeterInfo         `protobuf:"bytes,1,rep,name=greeters,proto3" json:"greeters,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGreetersResponse) Reset() {
+	*x = ListGreetersResponse{}
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGreetersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGreetersResponse) ProtoMessage() {}
+
+func (x *ListGreetersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_helloworld_v1_greeter_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGreetersResponse.ProtoReflect.Descriptor instead.
+func (*ListGreetersResponse) Descriptor() ([]byte, []int) {
+	return file_helloworld_v1_greeter_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListGreetersResponse) GetGreeters() []*GreeterInfo {
+	if x != nil {
+		return x.Greeters
+	}
+	return nil
+}
+
+func (x *ListGreetersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_helloworld_v1_greeter_proto protoreflect.FileDescriptor
 
 const file_helloworld_v1_greeter_proto_rawDesc = "" +
 	"\n" +
-	"\x1bhelloworld/v1/greeter.proto\x12\rhelloworld.v1\x1a\x1cgoogle/api/annotations.proto\"\"\n" +
-	"\fHelloRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"&\n" +
+	"\x1bhelloworld/v1/greeter.proto\x12\rhelloworld.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\".\n" +
+	"\fHelloRequest\x12\x1e\n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\xff\x01R\x04name\"&\n" +
 	"\n" +
 	"HelloReply\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2i\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xbc\x02\n" +
+	"\vGreeterInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05hello\x18\x02 \x01(\tR\x05hello\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12\x12\n" +
+	"\x04etag\x18\x05 \x01(\tR\x04etag\x12;\n" +
+	"\vdelete_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTime\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\b \x01(\tR\tupdatedBy\"8\n" +
+	"\x14CreateGreeterRequest\x12 \n" +
+	"\x05hello\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\xff\x01R\x05hello\",\n" +
+	"\x11GetGreeterRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\x02id\"n\n" +
+	"\x14UpdateGreeterRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\x02id\x12 \n" +
+	"\x05hello\x18\x02 \x01(\tB\n" +
+	"\xfaB\ar\x05\x10\x01\x18\xff\x01R\x05hello\x12\x1b\n" +
+	"\x04etag\x18\x03 \x01(\tB\a\xfaB\x04r\x02\x18@R\x04etag\"/\n" +
+	"\x14DeleteGreeterRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\x02id\"0\n" +
+	"\x15RestoreGreeterRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02 \x00R\x02id\"\xe1\x01\n" +
+	"\x13ListGreetersRequest\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00R\bpageSize\x12'\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tB\b\xfaB\x05r\x03\x18\x80\bR\tpageToken\x12 \n" +
+	"\x06filter\x18\x04 \x01(\tB\b\xfaB\x05r\x03\x18\x80\bR\x06filter\x12#\n" +
+	"\border_by\x18\x05 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\aorderBy\x12'\n" +
+	"\x0finclude_deleted\x18\x06 \x01(\bR\x0eincludeDeletedJ\x04\b\x01\x10\x02R\x05hello\"v\n" +
+	"\x14ListGreetersResponse\x126\n" +
+	"\bgreeters\x18\x01 \x03(\v2\x1a.helloworld.v1.GreeterInfoR\bgreeters\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xfd\x05\n" +
 	"\aGreeter\x12^\n" +
-	"\bSayHello\x12\x1b.helloworld.v1.HelloRequest\x1a\x19.helloworld.v1.HelloReply\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/helloworld/{name}Bl\n" +
+	"\bSayHello\x12\x1b.helloworld.v1.HelloRequest\x1a\x19.helloworld.v1.HelloReply\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/helloworld/{name}\x12i\n" +
+	"\rCreateGreeter\x12#.helloworld.v1.CreateGreeterRequest\x1a\x1a.helloworld.v1.GreeterInfo\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/greeters\x12e\n" +
+	"\n" +
+	"GetGreeter\x12 .helloworld.v1.GetGreeterRequest\x1a\x1a.helloworld.v1.GreeterInfo\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/greeters/{id}\x12n\n" +
+	"\rUpdateGreeter\x12#.helloworld.v1.UpdateGreeterRequest\x1a\x1a.helloworld.v1.GreeterInfo\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\x1a\x11/v1/greeters/{id}\x12g\n" +
+	"\rDeleteGreeter\x12#.helloworld.v1.DeleteGreeterRequest\x1a\x16.google.protobuf.Empty\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/greeters/{id}\x12m\n" +
+	"\fListGreeters\x12\".helloworld.v1.ListGreetersRequest\x1a#.helloworld.v1.ListGreetersResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/greeters\x12x\n" +
+	"\x0eRestoreGreeter\x12$.helloworld.v1.RestoreGreeterRequest\x1a\x1a.helloworld.v1.GreeterInfo\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/greeters/{id}:restoreBl\n" +
 	"\x1cdev.kratos.api.helloworld.v1B\x11HelloworldProtoV1P\x01Z7github.com/go-kratos/kratos-layout/api/helloworld/v1;v1b\x06proto3"
 
 var (
@@ -139,19 +668,45 @@ func file_helloworld_v1_greeter_proto_rawDescGZIP() []byte {
 	return file_helloworld_v1_greeter_proto_rawDescData
 }
 
-var file_helloworld_v1_greeter_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_helloworld_v1_greeter_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_helloworld_v1_greeter_proto_goTypes = []any{
-	(*HelloRequest)(nil), // 0: helloworld.v1.HelloRequest
-	(*HelloReply)(nil),   // 1: helloworld.v1.HelloReply
+	(*HelloRequest)(nil),          // 0: helloworld.v1.HelloRequest
+	(*HelloReply)(nil),            // 1: helloworld.v1.HelloReply
+	(*GreeterInfo)(nil),           // 2: helloworld.v1.GreeterInfo
+	(*CreateGreeterRequest)(nil),  // 3: helloworld.v1.CreateGreeterRequest
+	(*GetGreeterRequest)(nil),     // 4: helloworld.v1.GetGreeterRequest
+	(*UpdateGreeterRequest)(nil),  // 5: helloworld.v1.UpdateGreeterRequest
+	(*DeleteGreeterRequest)(nil),  // 6: helloworld.v1.DeleteGreeterRequest
+	(*RestoreGreeterRequest)(nil), // 7: helloworld.v1.RestoreGreeterRequest
+	(*ListGreetersRequest)(nil),   // 8: helloworld.v1.ListGreetersRequest
+	(*ListGreetersResponse)(nil),  // 9: helloworld.v1.ListGreetersResponse
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 11: google.protobuf.Empty
 }
 var file_helloworld_v1_greeter_proto_depIdxs = []int32{
-	0, // 0: helloworld.v1.Greeter.SayHello:input_type -> helloworld.v1.HelloRequest
-	1, // 1: helloworld.v1.Greeter.SayHello:output_type -> helloworld.v1.HelloReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	10, // 0: helloworld.v1.GreeterInfo.create_time:type_name -> google.protobuf.Timestamp
+	10, // 1: helloworld.v1.GreeterInfo.update_time:type_name -> google.protobuf.Timestamp
+	10, // 2: helloworld.v1.GreeterInfo.delete_time:type_name -> google.protobuf.Timestamp
+	2,  // 3: helloworld.v1.ListGreetersResponse.greeters:type_name -> helloworld.v1.GreeterInfo
+	0,  // 4: helloworld.v1.Greeter.SayHello:input_type -> helloworld.v1.HelloRequest
+	3,  // 5: helloworld.v1.Greeter.CreateGreeter:input_type -> helloworld.v1.CreateGreeterRequest
+	4,  // 6: helloworld.v1.Greeter.GetGreeter:input_type -> helloworld.v1.GetGreeterRequest
+	5,  // 7: helloworld.v1.Greeter.UpdateGreeter:input_type -> helloworld.v1.UpdateGreeterRequest
+	6,  // 8: helloworld.v1.Greeter.DeleteGreeter:input_type -> helloworld.v1.DeleteGreeterRequest
+	8,  // 9: helloworld.v1.Greeter.ListGreeters:input_type -> helloworld.v1.ListGreetersRequest
+	7,  // 10: helloworld.v1.Greeter.RestoreGreeter:input_type -> helloworld.v1.RestoreGreeterRequest
+	1,  // 11: helloworld.v1.Greeter.SayHello:output_type -> helloworld.v1.HelloReply
+	2,  // 12: helloworld.v1.Greeter.CreateGreeter:output_type -> helloworld.v1.GreeterInfo
+	2,  // 13: helloworld.v1.Greeter.GetGreeter:output_type -> helloworld.v1.GreeterInfo
+	2,  // 14: helloworld.v1.Greeter.UpdateGreeter:output_type -> helloworld.v1.GreeterInfo
+	11, // 15: helloworld.v1.Greeter.DeleteGreeter:output_type -> google.protobuf.Empty
+	9,  // 16: helloworld.v1.Greeter.ListGreeters:output_type -> helloworld.v1.ListGreetersResponse
+	2,  // 17: helloworld.v1.Greeter.RestoreGreeter:output_type -> helloworld.v1.GreeterInfo
+	11, // [11:18] is the sub-list for method output_type
+	4,  // [4:11] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_helloworld_v1_greeter_proto_init() }
@@ -165,7 +720,7 @@ func file_helloworld_v1_greeter_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_helloworld_v1_greeter_proto_rawDesc), len(file_helloworld_v1_greeter_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -12,6 +12,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,7 +21,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Greeter_SayHello_FullMethodName = "/helloworld.v1.Greeter/SayHello"
+	Greeter_SayHello_FullMethodName       = "/helloworld.v1.Greeter/SayHello"
+	Greeter_CreateGreeter_FullMethodName  = "/helloworld.v1.Greeter/CreateGreeter"
+	Greeter_GetGreeter_FullMethodName     = "/helloworld.v1.Greeter/GetGreeter"
+	Greeter_UpdateGreeter_FullMethodName  = "/helloworld.v1.Greeter/UpdateGreeter"
+	Greeter_DeleteGreeter_FullMethodName  = "/helloworld.v1.Greeter/DeleteGreeter"
+	Greeter_ListGreeters_FullMethodName   = "/helloworld.v1.Greeter/ListGreeters"
+	Greeter_RestoreGreeter_FullMethodName = "/helloworld.v1.Greeter/RestoreGreeter"
 )
 
 // GreeterClient is the client API for Greeter service.
@@ -31,6 +38,18 @@ const (
 type GreeterClient interface {
 	// Sends a greeting
 	SayHello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloReply, error)
+	// Creates a greeter
+	CreateGreeter(ctx context.Context, in *CreateGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error)
+	// Gets a greeter by id
+	GetGreeter(ctx context.Context, in *GetGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error)
+	// Updates the hello of a greeter
+	UpdateGreeter(ctx context.Context, in *UpdateGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error)
+	// Deletes a greeter
+	DeleteGreeter(ctx context.Context, in *DeleteGreeterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists greeters page by page
+	ListGreeters(ctx context.Context, in *ListGreetersRequest, opts ...grpc.CallOption) (*ListGreetersResponse, error)
+	// Restores a deleted greeter
+	RestoreGreeter(ctx context.Context, in *RestoreGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error)
 }
 
 type greeterClient struct {
@@ -51,6 +70,66 @@ func (c *greeterClient) SayHello(ctx context.Context, in *HelloRequest, opts ...
 	return out, nil
 }
 
+func (c *greeterClient) CreateGreeter(ctx context.Context, in *CreateGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GreeterInfo)
+	err := c.cc.Invoke(ctx, Greeter_CreateGreeter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetGreeter(ctx context.Context, in *GetGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GreeterInfo)
+	err := c.cc.Invoke(ctx, Greeter_GetGreeter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) UpdateGreeter(ctx context.Context, in *UpdateGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GreeterInfo)
+	err := c.cc.Invoke(ctx, Greeter_UpdateGreeter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) DeleteGreeter(ctx context.Context, in *DeleteGreeterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Greeter_DeleteGreeter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) ListGreeters(ctx context.Context, in *ListGreetersRequest, opts ...grpc.CallOption) (*ListGreetersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGreetersResponse)
+	err := c.cc.Invoke(ctx, Greeter_ListGreeters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) RestoreGreeter(ctx context.Context, in *RestoreGreeterRequest, opts ...grpc.CallOption) (*GreeterInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GreeterInfo)
+	err := c.cc.Invoke(ctx, Greeter_RestoreGreeter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GreeterServer is the server API for Greeter service.
 // All implementations must embed UnimplementedGreeterServer
 // for forward compatibility.
@@ -59,6 +138,18 @@ func (c *greeterClient) SayHello(ctx context.Context, in *HelloRequest, opts ...
 type GreeterServer interface {
 	// Sends a greeting
 	SayHello(context.Context, *HelloRequest) (*HelloReply, error)
+	// Creates a greeter
+	CreateGreeter(context.Context, *CreateGreeterRequest) (*GreeterInfo, error)
+	// Gets a greeter by id
+	GetGreeter(context.Context, *GetGreeterRequest) (*GreeterInfo, error)
+	// Updates the hello of a greeter
+	UpdateGreeter(context.Context, *UpdateGreeterRequest) (*GreeterInfo, error)
+	// Deletes a greeter
+	DeleteGreeter(context.Context, *DeleteGreeterRequest) (*emptypb.Empty, error)
+	// Lists greeters page by page
+	ListGreeters(context.Context, *ListGreetersRequest) (*ListGreetersResponse, error)
+	// Restores a deleted greeter
+	RestoreGreeter(context.Context, *RestoreGreeterRequest) (*GreeterInfo, error)
 	mustEmbedUnimplementedGreeterServer()
 }
 
@@ -72,6 +163,24 @@ type UnimplementedGreeterServer struct{}
 func (UnimplementedGreeterServer) SayHello(context.Context, *HelloRequest) (*HelloReply, error) {
 	return nil, status.Error(codes.Unimplemented, "method SayHello not implemented")
 }
+func (UnimplementedGreeterServer) CreateGreeter(context.Context, *CreateGreeterRequest) (*GreeterInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateGreeter not implemented")
+}
+func (UnimplementedGreeterServer) GetGreeter(context.Context, *GetGreeterRequest) (*GreeterInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetGreeter not implemented")
+}
+func (UnimplementedGreeterServer) UpdateGreeter(context.Context, *UpdateGreeterRequest) (*GreeterInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateGreeter not implemented")
+}
+func (UnimplementedGreeterServer) DeleteGreeter(context.Context, *DeleteGreeterRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteGreeter not implemented")
+}
+func (UnimplementedGreeterServer) ListGreeters(context.Context, *ListGreetersRequest) (*ListGreetersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListGreeters not implemented")
+}
+func (UnimplementedGreeterServer) RestoreGreeter(context.Context, *RestoreGreeterRequest) (*GreeterInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreGreeter not implemented")
+}
 func (UnimplementedGreeterServer) mustEmbedUnimplementedGreeterServer() {}
 func (UnimplementedGreeterServer) testEmbeddedByValue()                 {}
 
@@ -111,6 +220,114 @@ func _Greeter_SayHello_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_CreateGreeter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGreeterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).CreateGreeter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Greeter_CreateGreeter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).CreateGreeter(ctx, req.(*CreateGreeterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetGreeter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGreeterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetGreeter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Greeter_GetGreeter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetGreeter(ctx, req.(*GetGreeterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_UpdateGreeter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGreeterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).UpdateGreeter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Greeter_UpdateGreeter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).UpdateGreeter(ctx, req.(*UpdateGreeterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_DeleteGreeter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGreeterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).DeleteGreeter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Greeter_DeleteGreeter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).DeleteGreeter(ctx, req.(*DeleteGreeterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_ListGreeters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGreetersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).ListGreeters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Greeter_ListGreeters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).ListGreeters(ctx, req.(*ListGreetersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_RestoreGreeter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreGreeterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).RestoreGreeter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Greeter_RestoreGreeter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).RestoreGreeter(ctx, req.(*RestoreGreeterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Greeter_ServiceDesc is the grpc.ServiceDesc for Greeter service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -122,6 +339,30 @@ var Greeter_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SayHello",
 			Handler:    _Greeter_SayHello_Handler,
 		},
+		{
+			MethodName: "CreateGreeter",
+			Handler:    _Greeter_CreateGreeter_Handler,
+		},
+		{
+			MethodName: "GetGreeter",
+			Handler:    _Greeter_GetGreeter_Handler,
+		},
+		{
+			MethodName: "UpdateGreeter",
+			Handler:    _Greeter_UpdateGreeter_Handler,
+		},
+		{
+			MethodName: "DeleteGreeter",
+			Handler:    _Greeter_DeleteGreeter_Handler,
+		},
+		{
+			MethodName: "ListGreeters",
+			Handler:    _Greeter_ListGreeters_Handler,
+		},
+		{
+			MethodName: "RestoreGreeter",
+			Handler:    _Greeter_RestoreGreeter_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "helloworld/v1/greeter.proto",
@@ -11,6 +11,7 @@ import (
 
 	http "github.com/go-kratos/kratos/v2/transport/http"
 	binding "github.com/go-kratos/kratos/v2/transport/http/binding"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,16 +21,40 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationGreeterCreateGreeter = "/helloworld.v1.Greeter/CreateGreeter"
+const OperationGreeterDeleteGreeter = "/helloworld.v1.Greeter/DeleteGreeter"
+const OperationGreeterGetGreeter = "/helloworld.v1.Greeter/GetGreeter"
+const OperationGreeterListGreeters = "/helloworld.v1.Greeter/ListGreeters"
+const OperationGreeterRestoreGreeter = "/helloworld.v1.Greeter/RestoreGreeter"
 const OperationGreeterSayHello = "/helloworld.v1.Greeter/SayHello"
+const OperationGreeterUpdateGreeter = "/helloworld.v1.Greeter/UpdateGreeter"
 
 type GreeterHTTPServer interface {
+	// CreateGreeter Creates a greeter
+	CreateGreeter(context.Context, *CreateGreeterRequest) (*GreeterInfo, error)
+	// DeleteGreeter Deletes a greeter
+	DeleteGreeter(context.Context, *DeleteGreeterRequest) (*emptypb.Empty, error)
+	// GetGreeter Gets a greeter by id
+	GetGreeter(context.Context, *GetGreeterRequest) (*GreeterInfo, error)
+	// ListGreeters Lists greeters page by page
+	ListGreeters(context.Context, *ListGreetersRequest) (*ListGreetersResponse, error)
+	// RestoreGreeter Restores a deleted greeter
+	RestoreGreeter(context.Context, *RestoreGreeterRequest) (*GreeterInfo, error)
 	// SayHello Sends a greeting
 	SayHello(context.Context, *HelloRequest) (*HelloReply, error)
+	// UpdateGreeter Updates the hello of a greeter
+	UpdateGreeter(context.Context, *UpdateGreeterRequest) (*GreeterInfo, error)
 }
 
 func RegisterGreeterHTTPServer(s *http.Server, srv GreeterHTTPServer) {
 	r := s.Route("/")
 	r.GET("/helloworld/{name}", _Greeter_SayHello0_HTTP_Handler(srv))
+	r.POST("/v1/greeters", _Greeter_CreateGreeter0_HTTP_Handler(srv))
+	r.GET("/v1/greeters/{id}", _Greeter_GetGreeter0_HTTP_Handler(srv))
+	r.PUT("/v1/greeters/{id}", _Greeter_UpdateGreeter0_HTTP_Handler(srv))
+	r.DELETE("/v1/greeters/{id}", _Greeter_DeleteGreeter0_HTTP_Handler(srv))
+	r.GET("/v1/greeters", _Greeter_ListGreeters0_HTTP_Handler(srv))
+	r.POST("/v1/greeters/{id}:restore", _Greeter_RestoreGreeter0_HTTP_Handler(srv))
 }
 
 func _Greeter_SayHello0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Context) error {
@@ -54,9 +79,156 @@ func _Greeter_SayHello0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Contex
 	}
 }
 
+func _Greeter_CreateGreeter0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CreateGreeterRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationGreeterCreateGreeter)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CreateGreeter(ctx, req.(*CreateGreeterRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GreeterInfo)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Greeter_GetGreeter0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetGreeterRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationGreeterGetGreeter)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetGreeter(ctx, req.(*GetGreeterRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GreeterInfo)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Greeter_UpdateGreeter0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UpdateGreeterRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationGreeterUpdateGreeter)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateGreeter(ctx, req.(*UpdateGreeterRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GreeterInfo)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Greeter_DeleteGreeter0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeleteGreeterRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationGreeterDeleteGreeter)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DeleteGreeter(ctx, req.(*DeleteGreeterRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Greeter_ListGreeters0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListGreetersRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationGreeterListGreeters)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListGreeters(ctx, req.(*ListGreetersRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListGreetersResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Greeter_RestoreGreeter0_HTTP_Handler(srv GreeterHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in RestoreGreeterRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationGreeterRestoreGreeter)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RestoreGreeter(ctx, req.(*RestoreGreeterRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GreeterInfo)
+		return ctx.Result(200, reply)
+	}
+}
+
 type GreeterHTTPClient interface {
+	// CreateGreeter Creates a greeter
+	CreateGreeter(ctx context.Context, req *CreateGreeterRequest, opts ...http.CallOption) (rsp *GreeterInfo, err error)
+	// DeleteGreeter Deletes a greeter
+	DeleteGreeter(ctx context.Context, req *DeleteGreeterRequest, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	// GetGreeter Gets a greeter by id
+	GetGreeter(ctx context.Context, req *GetGreeterRequest, opts ...http.CallOption) (rsp *GreeterInfo, err error)
+	// ListGreeters Lists greeters page by page
+	ListGreeters(ctx context.Context, req *ListGreetersRequest, opts ...http.CallOption) (rsp *ListGreetersResponse, err error)
+	// RestoreGreeter Restores a deleted greeter
+	RestoreGreeter(ctx context.Context, req *RestoreGreeterRequest, opts ...http.CallOption) (rsp *GreeterInfo, err error)
 	// SayHello Sends a greeting
 	SayHello(ctx context.Context, req *HelloRequest, opts ...http.CallOption) (rsp *HelloReply, err error)
+	// UpdateGreeter Updates the hello of a greeter
+	UpdateGreeter(ctx context.Context, req *UpdateGreeterRequest, opts ...http.CallOption) (rsp *GreeterInfo, err error)
 }
 
 type GreeterHTTPClientImpl struct {
@@ -67,6 +239,76 @@ func NewGreeterHTTPClient(client *http.Client) GreeterHTTPClient {
 	return &GreeterHTTPClientImpl{client}
 }
 
+// CreateGreeter Creates a greeter
+func (c *GreeterHTTPClientImpl) CreateGreeter(ctx context.Context, in *CreateGreeterRequest, opts ...http.CallOption) (*GreeterInfo, error) {
+	var out GreeterInfo
+	pattern := "/v1/greeters"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationGreeterCreateGreeter))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteGreeter Deletes a greeter
+func (c *GreeterHTTPClientImpl) DeleteGreeter(ctx context.Context, in *DeleteGreeterRequest, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "/v1/greeters/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationGreeterDeleteGreeter))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGreeter Gets a greeter by id
+func (c *GreeterHTTPClientImpl) GetGreeter(ctx context.Context, in *GetGreeterRequest, opts ...http.CallOption) (*GreeterInfo, error) {
+	var out GreeterInfo
+	pattern := "/v1/greeters/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationGreeterGetGreeter))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListGreeters Lists greeters page by page
+func (c *GreeterHTTPClientImpl) ListGreeters(ctx context.Context, in *ListGreetersRequest, opts ...http.CallOption) (*ListGreetersResponse, error) {
+	var out ListGreetersResponse
+	pattern := "/v1/greeters"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationGreeterListGreeters))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RestoreGreeter Restores a deleted greeter
+func (c *GreeterHTTPClientImpl) RestoreGreeter(ctx context.Context, in *RestoreGreeterRequest, opts ...http.CallOption) (*GreeterInfo, error) {
+	var out GreeterInfo
+	pattern := "/v1/greeters/{id}:restore"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationGreeterRestoreGreeter))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // SayHello Sends a greeting
 func (c *GreeterHTTPClientImpl) SayHello(ctx context.Context, in *HelloRequest, opts ...http.CallOption) (*HelloReply, error) {
 	var out HelloReply
@@ -80,3 +322,17 @@ func (c *GreeterHTTPClientImpl) SayHello(ctx context.Context, in *HelloRequest,
 	}
 	return &out, nil
 }
+
+// UpdateGreeter Updates the hello of a greeter
+func (c *GreeterHTTPClientImpl) UpdateGreeter(ctx context.Context, in *UpdateGreeterRequest, opts ...http.CallOption) (*GreeterInfo, error) {
+	var out GreeterInfo
+	pattern := "/v1/greeters/{id}"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationGreeterUpdateGreeter))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "PUT", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
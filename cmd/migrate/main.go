@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/data"
+	"github.com/go-kratos/kratos-layout/internal/data/migrations"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+// flagconf is the config file path. When empty, config is loaded from Apollo like the server.
+var flagconf string
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "", "config file path, eg: -conf configs/config.yaml")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage: migrate [-conf path] <command> [args]
+
+Commands:
+  up [N]         apply all or the next N pending migrations
+  down [N]       revert the last N applied migrations (default 1)
+  down -all      revert every applied migration
+  status         show the state of every migration
+  force VERSION  mark VERSION as cleanly applied without running SQL
+
+Flags:
+`)
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(cmd string, args []string) error {
+	bc, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	ormDB, err := orm.MakeDB(data.NewDBConfig(bc.Data.Database))
+	if err != nil {
+		return err
+	}
+	defer ormDB.Close()
+
+	migrator, err := orm.NewMigrator(ormDB.GetDB(), migrations.FS, migrations.Dir(bc.Data.Database.Driver))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch cmd {
+	case "up":
+		steps, err := intArg(args, 0)
+		if err != nil {
+			return err
+		}
+		n, err := migrator.Up(ctx, steps)
+		fmt.Printf("applied %d migration(s)\n", n)
+		return err
+	case "down":
+		fs := flag.NewFlagSet("down", flag.ContinueOnError)
+		all := fs.Bool("all", false, "revert every applied migration")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		var n int
+		if *all {
+			if fs.NArg() > 0 {
+				return fmt.Errorf("down -all takes no step count")
+			}
+			n, err = migrator.DownAll(ctx)
+		} else {
+			var steps int
+			if steps, err = intArg(fs.Args(), 1); err != nil {
+				return err
+			}
+			n, err = migrator.Down(ctx, steps)
+		}
+		fmt.Printf("reverted %d migration(s)\n", n)
+		return err
+	case "status":
+		return printStatus(ctx, migrator)
+	case "force":
+		if len(args) != 1 {
+			return fmt.Errorf("force requires a version")
+		}
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", args[0], err)
+		}
+		return migrator.Force(ctx, version)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func printStatus(ctx context.Context, migrator *orm.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, st := range statuses {
+		state, appliedAt := "pending", ""
+		if st.Applied {
+			state, appliedAt = "applied", st.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if st.Dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", st.Version, st.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
+
+// intArg returns the step count in args, or def when there is none. An
+// explicit count must be positive.
+func intArg(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid step count %q: %w", args[0], err)
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid step count %d: must be positive", n)
+	}
+	return n, nil
+}
+
+func loadConfig() (*conf.Bootstrap, error) {
+	var (
+		source config.Source
+		key    string
+	)
+	if flagconf != "" {
+		source = file.NewSource(flagconf)
+	} else {
+		source = conf.NewApolloSource()
+		key = conf.ApolloKey
+	}
+
+	c := config.New(config.WithSource(source))
+	defer c.Close()
+
+	if err := c.Load(); err != nil {
+		return nil, fmt.Errorf("load config failed: %w", err)
+	}
+
+	var bc conf.Bootstrap
+	var err error
+	if key == "" {
+		err = c.Scan(&bc)
+	} else {
+		err = c.Value(key).Scan(&bc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan config failed: %w", err)
+	}
+	if bc.Data == nil || bc.Data.Database == nil {
+		return nil, fmt.Errorf("data.database config is missing")
+	}
+	return &bc, nil
+}
@@ -3,20 +3,24 @@ package main
 import (
 	"os"
 
-	"github.com/go-kratos/kratos/contrib/config/apollo/v2"
 	"github.com/go-kratos/kratos/contrib/registry/nacos/v2"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/encoding/json"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/go-kratos/kratos/v2/transport/http"
 	_ "go.uber.org/automaxprocs"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/data"
 	"github.com/go-kratos/kratos-layout/pkg/env"
+	"github.com/go-kratos/kratos-layout/pkg/event"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/requestid"
 	"github.com/go-kratos/kratos-layout/pkg/registry"
 
 	zaplog "github.com/go-kratos/kratos-layout/pkg/log"
@@ -53,7 +57,7 @@ func init() {
 	}
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, r *nacos.Registry) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, es *event.Server, relay *data.OutboxRelay, r *nacos.Registry) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -63,25 +67,15 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, r *nacos.Regist
 		kratos.Server(
 			gs,
 			hs,
+			es,
+			relay,
 		),
 		kratos.Registrar(r),
 	)
 }
 
 func main() {
-	logger := zaplog.InitDefaultLogger(zapcore.DebugLevel)
-
-	c := config.New(
-		config.WithSource(
-			apollo.NewSource(
-				apollo.WithAppID(env.GetOrDefault("APOLLO_APP_ID", "kratos_layout")),
-				apollo.WithCluster(env.GetOrDefault("APOLLO_CLUSTER", "dev")),
-				apollo.WithEndpoint(env.GetOrDefault("APOLLO_ENDPOINT", "http://localhost:8080")),
-				apollo.WithNamespace(env.GetOrDefault("APOLLO_NAMESPACE", "application,bootstrap.yaml")),
-				apollo.WithSecret(env.GetOrDefault("APOLLO_SECRET", "fc4cacadc4cb486b91419d67f6d7918b")),
-			),
-		),
-	)
+	c := config.New(config.WithSource(conf.NewApolloSource()))
 	defer c.Close()
 
 	if err := c.Load(); err != nil {
@@ -89,9 +83,27 @@ func main() {
 	}
 
 	var bc conf.Bootstrap
-	if err := c.Value("bootstrap").Scan(&bc); err != nil {
+	if err := c.Value(conf.ApolloKey).Scan(&bc); err != nil {
+		panic(err)
+	}
+
+	sampling, err := samplingConfig(bc.Log.GetSampling())
+	if err != nil {
 		panic(err)
 	}
+	zapLogger := zaplog.InitDefaultLogger(zapcore.DebugLevel,
+		zaplog.WithSampling(sampling),
+		zaplog.WithRedaction(zaplog.NewRedactor()),
+		// log.With below adds one more frame between log.Helper and zap.
+		zap.AddCallerSkip(1),
+	)
+	logger := log.With(zapLogger,
+		"service_name", Name,
+		"service_version", Version,
+		"trace_id", tracing.TraceID(),
+		"span_id", tracing.SpanID(),
+		"request_id", requestid.Valuer(),
+	)
 
 	r, err := registry.NewNacosRegistryFromEnv()
 	if err != nil {
@@ -109,3 +121,16 @@ func main() {
 		panic(err)
 	}
 }
+
+// samplingConfig returns the log sampling of c, or the default sampling
+// when c is not set.
+func samplingConfig(c *conf.Log_Sampling) (*zaplog.SamplingConfig, error) {
+	if c == nil {
+		return zaplog.DefaultSamplingConfig(), nil
+	}
+	rules := make(map[string]zaplog.SamplingRule, len(c.Levels))
+	for name, rule := range c.Levels {
+		rules[name] = zaplog.SamplingRule{Initial: int(rule.GetInitial()), Thereafter: int(rule.GetThereafter())}
+	}
+	return zaplog.NewSamplingConfig(c.Tick.AsDuration(), rules)
+}
@@ -28,13 +28,22 @@ func wireApp(confServer *conf.Server, confData *conf.Data, registry *nacos.Regis
 	if err != nil {
 		return nil, nil, err
 	}
+	store := data.NewIdempotencyStore(dataData)
 	greeterRepo := data.NewGreeterRepo(dataData, logger)
-	greeterUsecase := biz.NewGreeterUsecase(greeterRepo)
+	transaction := data.NewTransaction(dataData)
+	eventPublisher := data.NewEventPublisher(dataData)
+	greeterUsecase := biz.NewGreeterUsecase(greeterRepo, transaction, eventPublisher, logger)
 	greeterService := service.NewGreeterService(greeterUsecase)
 	healthService := service.NewHealthService()
-	grpcServer := server.NewGRPCServer(confServer, greeterService, healthService, logger)
-	httpServer := server.NewHTTPServer(confServer, greeterService, healthService, logger)
-	app := newApp(logger, grpcServer, httpServer, registry)
+	grpcServer := server.NewGRPCServer(confServer, store, greeterService, healthService, logger)
+	httpServer := server.NewHTTPServer(confServer, store, greeterService, healthService, logger)
+	subscriber := data.NewSubscriber(confServer, dataData, logger)
+	greeterEventService := service.NewGreeterEventService(logger)
+	eventServer := server.NewEventServer(confServer, subscriber, store, greeterEventService, logger)
+	locker := data.NewLocker(dataData)
+	publisher := data.NewBroker(confData, dataData)
+	outboxRelay := data.NewOutboxRelay(confData, dataData, locker, publisher, logger)
+	app := newApp(logger, grpcServer, httpServer, eventServer, outboxRelay, registry)
 	return app, func() {
 		cleanup()
 	}, nil
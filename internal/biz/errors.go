@@ -0,0 +1,20 @@
+package biz
+
+import v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+
+// Metadata keys set on errors about a resource.
+const (
+	MetadataResource = "resource"
+	MetadataID       = "id"
+)
+
+var (
+	// ErrAlreadyExists is returned when a write violates a unique key.
+	ErrAlreadyExists = v1.ErrorAlreadyExists("resource already exists")
+	// ErrAborted is returned when a transaction is aborted by a deadlock or
+	// lock contention. Retrying it may succeed.
+	ErrAborted = v1.ErrorAborted("transaction aborted")
+	// ErrVersionMismatch is returned when a conditional update is based on
+	// an outdated version of a resource.
+	ErrVersionMismatch = v1.ErrorVersionMismatch("resource was modified")
+)
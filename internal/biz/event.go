@@ -0,0 +1,25 @@
+package biz
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Event is a domain event about an aggregate.
+type Event struct {
+	// AggregateID identifies the aggregate the event is about. Events of one
+	// aggregate are delivered in the order they were published.
+	AggregateID string
+	// Payload is the event. It is delivered to the topic named after its
+	// full name, e.g. helloworld.v1.GreeterCreated.
+	Payload proto.Message
+}
+
+// EventPublisher publishes domain events to other services.
+type EventPublisher interface {
+	// Publish records events for delivery. Within Transaction.InTx they are
+	// recorded in the transaction, so they are delivered if and only if it
+	// commits. Delivery is asynchronous and at least once.
+	Publish(ctx context.Context, events ...*Event) error
+}
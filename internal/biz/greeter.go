@@ -2,21 +2,39 @@ package biz
 
 import (
 	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+	zaplog "github.com/go-kratos/kratos-layout/pkg/log"
 
-	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 )
 
 var (
-	// ErrUserNotFound is user not found.
-	ErrUserNotFound = errors.NotFound(v1.ErrorReason_USER_NOT_FOUND.String(), "user not found")
+	// ErrGreeterNotFound is returned when a greeter does not exist.
+	ErrGreeterNotFound = v1.ErrorGreeterNotFound("greeter not found")
+	// ErrHelloRequired is returned when a greeter is saved without a hello.
+	ErrHelloRequired = v1.ErrorInvalidArgument("hello is required")
 )
 
 // Greeter is a Greeter model.
 type Greeter struct {
+	ID    int64
 	Hello string
+	// Version increases on every update. An update with a non-zero Version
+	// only applies to that version.
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// DeletedAt is zero unless the Greeter is deleted.
+	DeletedAt time.Time
+	// CreatedBy and UpdatedBy are the principals that created and last
+	// updated the Greeter.
+	CreatedBy string
+	UpdatedBy string
 }
 
 // GreeterRepo is a Greater repo.
@@ -26,20 +44,91 @@ type GreeterRepo interface {
 	FindByID(context.Context, int64) (*Greeter, error)
 	ListByHello(context.Context, string) ([]*Greeter, error)
 	ListAll(context.Context) ([]*Greeter, error)
+	List(context.Context, *ListQuery) (*Page[*Greeter], error)
+	Delete(context.Context, int64) error
+	Restore(context.Context, int64) (*Greeter, error)
 }
 
+// The usecase logs at most logLimit lines per logInterval for each key.
+const (
+	logLimit    = 10
+	logInterval = time.Second
+)
+
 // GreeterUsecase is a Greeter usecase.
 type GreeterUsecase struct {
-	repo GreeterRepo
+	repo   GreeterRepo
+	tx     Transaction
+	events EventPublisher
+	log    *zaplog.RateLimitedHelper
 }
 
 // NewGreeterUsecase new a Greeter usecase.
-func NewGreeterUsecase(repo GreeterRepo) *GreeterUsecase {
-	return &GreeterUsecase{repo: repo}
+func NewGreeterUsecase(repo GreeterRepo, tx Transaction, events EventPublisher, logger log.Logger) *GreeterUsecase {
+	return &GreeterUsecase{
+		repo:   repo,
+		tx:     tx,
+		events: events,
+		log:    zaplog.NewRateLimitedHelper(logger, logLimit, logInterval),
+	}
 }
 
-// CreateGreeter creates a Greeter, and returns the new Greeter.
+// CreateGreeter creates a Greeter, and returns the new Greeter. It publishes
+// a v1.GreeterCreated event along with it.
 func (uc *GreeterUsecase) CreateGreeter(ctx context.Context, g *Greeter) (*Greeter, error) {
-	log.Infof("CreateGreeter: %v", g.Hello)
-	return uc.repo.Save(ctx, g)
+	uc.log.WithContext(ctx).Infof("CreateGreeter", "CreateGreeter: %v", g.Hello)
+	if g.Hello == "" {
+		return nil, ErrHelloRequired
+	}
+	var saved *Greeter
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if saved, err = uc.repo.Save(ctx, g); err != nil {
+			return err
+		}
+		return uc.events.Publish(ctx, &Event{
+			AggregateID: strconv.FormatInt(saved.ID, 10),
+			Payload: &v1.GreeterCreated{
+				Id:         saved.ID,
+				Hello:      saved.Hello,
+				CreateTime: timestamppb.New(saved.CreatedAt),
+				CreatedBy:  saved.CreatedBy,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// GetGreeter returns the Greeter with id.
+func (uc *GreeterUsecase) GetGreeter(ctx context.Context, id int64) (*Greeter, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// UpdateGreeter updates the hello of a Greeter, and returns the updated Greeter.
+func (uc *GreeterUsecase) UpdateGreeter(ctx context.Context, g *Greeter) (*Greeter, error) {
+	uc.log.WithContext(ctx).Infof("UpdateGreeter", "UpdateGreeter: %d", g.ID)
+	if g.Hello == "" {
+		return nil, ErrHelloRequired
+	}
+	return uc.repo.Update(ctx, g)
+}
+
+// DeleteGreeter deletes the Greeter with id.
+func (uc *GreeterUsecase) DeleteGreeter(ctx context.Context, id int64) error {
+	uc.log.WithContext(ctx).Infof("DeleteGreeter", "DeleteGreeter: %d", id)
+	return uc.repo.Delete(ctx, id)
+}
+
+// RestoreGreeter restores the deleted Greeter with id, and returns it.
+func (uc *GreeterUsecase) RestoreGreeter(ctx context.Context, id int64) (*Greeter, error) {
+	uc.log.WithContext(ctx).Infof("RestoreGreeter", "RestoreGreeter: %d", id)
+	return uc.repo.Restore(ctx, id)
+}
+
+// ListGreeters returns one page of the Greeters selected by q.
+func (uc *GreeterUsecase) ListGreeters(ctx context.Context, q *ListQuery) (*Page[*Greeter], error) {
+	return uc.repo.List(ctx, q)
 }
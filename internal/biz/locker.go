@@ -0,0 +1,40 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrLockNotAcquired is returned by TryLock when the key is held by another owner.
+	ErrLockNotAcquired = errors.New("lock not acquired")
+	// ErrLockNotHeld is returned when a lock is released or extended after it
+	// expired, possibly having been acquired by another owner since.
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+// Lock is an acquired lock.
+type Lock struct {
+	Key string
+	// Owner identifies this acquisition; only its owner can release or extend the lock.
+	Owner string
+	// Fence increases with every acquisition of Key. Storage written under the
+	// lock can reject writes carrying a fence lower than the last one it saw,
+	// which stops a holder whose lease expired unnoticed.
+	Fence int64
+}
+
+// Locker provides mutual exclusion across instances. Locks are leases: a
+// lock is released when its TTL elapses, so holders running longer than the
+// TTL must extend it.
+type Locker interface {
+	// TryLock acquires key for ttl, or returns ErrLockNotAcquired when it is held.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error)
+	// Lock acquires key for ttl, waiting until it is free or ctx is done.
+	Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error)
+	// Unlock releases lock, or returns ErrLockNotHeld when it already expired.
+	Unlock(ctx context.Context, lock *Lock) error
+	// Extend resets the TTL of lock, or returns ErrLockNotHeld when it already expired.
+	Extend(ctx context.Context, lock *Lock, ttl time.Duration) error
+}
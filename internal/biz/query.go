@@ -0,0 +1,24 @@
+package biz
+
+import "github.com/go-kratos/kratos-layout/pkg/query"
+
+// ListQuery selects one page of a filtered, ordered list.
+type ListQuery struct {
+	Filter query.Expr
+	// OrderBy ends with a unique field, which keyset pagination relies on.
+	OrderBy  []query.OrderField
+	PageSize int
+	// After holds the OrderBy values of the last row of the previous page,
+	// and is nil for the first page.
+	After []any
+	// IncludeDeleted also lists soft-deleted rows.
+	IncludeDeleted bool
+}
+
+// Page is one page of a list.
+type Page[T any] struct {
+	Items []T
+	// Next holds the OrderBy values of the last item when more items follow,
+	// and is nil on the last page.
+	Next []any
+}
@@ -0,0 +1,12 @@
+package biz
+
+import "context"
+
+// Transaction runs business operations atomically.
+type Transaction interface {
+	// InTx calls fn within a transaction. Repository calls made with the ctx
+	// passed to fn join the transaction. The transaction is committed when fn
+	// returns nil and rolled back when fn returns an error or panics.
+	// Nested calls run in a savepoint of the outer transaction.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,23 @@
+package conf
+
+import (
+	"github.com/go-kratos/kratos/contrib/config/apollo/v2"
+	"github.com/go-kratos/kratos/v2/config"
+
+	"github.com/go-kratos/kratos-layout/pkg/env"
+)
+
+// ApolloKey is the key of the Bootstrap config in the Apollo source.
+const ApolloKey = "bootstrap"
+
+// NewApolloSource creates the Apollo config source of the service,
+// configured by the APOLLO_* environment variables.
+func NewApolloSource() config.Source {
+	return apollo.NewSource(
+		apollo.WithAppID(env.GetOrDefault("APOLLO_APP_ID", "kratos_layout")),
+		apollo.WithCluster(env.GetOrDefault("APOLLO_CLUSTER", "dev")),
+		apollo.WithEndpoint(env.GetOrDefault("APOLLO_ENDPOINT", "http://localhost:8080")),
+		apollo.WithNamespace(env.GetOrDefault("APOLLO_NAMESPACE", "application,bootstrap.yaml")),
+		apollo.WithSecret(env.GetOrDefault("APOLLO_SECRET", "fc4cacadc4cb486b91419d67f6d7918b")),
+	)
+}
@@ -27,6 +27,7 @@ type Bootstrap struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Log           *Log                   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -75,17 +76,74 @@ func (x *Bootstrap) GetData() *Data {
 	return nil
 }
 
+func (x *Bootstrap) GetLog() *Log {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+type Log struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sampling defaults to 100 initial and 100 thereafter per second for
+	// debug and info entries.
+	Sampling      *Log_Sampling `protobuf:"bytes,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log) Reset() {
+	*x = Log{}
+	mi := &file_conf_conf_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log) ProtoMessage() {}
+
+func (x *Log) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log.ProtoReflect.Descriptor instead.
+func (*Log) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Log) GetSampling() *Log_Sampling {
+	if x != nil {
+		return x.Sampling
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Idempotency   *Server_Idempotency    `protobuf:"bytes,3,opt,name=idempotency,proto3" json:"idempotency,omitempty"`
+	Event         *Server_Event          `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	Principal     *Server_Principal      `protobuf:"bytes,5,opt,name=principal,proto3" json:"principal,omitempty"`
+	AccessLog     *Server_AccessLog      `protobuf:"bytes,6,opt,name=access_log,json=accessLog,proto3" json:"access_log,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Server) Reset() {
 	*x = Server{}
-	mi := &file_conf_conf_proto_msgTypes[1]
+	mi := &file_conf_conf_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -97,7 +155,7 @@ func (x *Server) String() string {
 func (*Server) ProtoMessage() {}
 
 func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[1]
+	mi := &file_conf_conf_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -110,7 +168,7 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server.ProtoReflect.Descriptor instead.
 func (*Server) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Server) GetHttp() *Server_HTTP {
@@ -127,17 +185,46 @@ func (x *Server) GetGrpc() *Server_GRPC {
 	return nil
 }
 
+func (x *Server) GetIdempotency() *Server_Idempotency {
+	if x != nil {
+		return x.Idempotency
+	}
+	return nil
+}
+
+func (x *Server) GetEvent() *Server_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *Server) GetPrincipal() *Server_Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+func (x *Server) GetAccessLog() *Server_AccessLog {
+	if x != nil {
+		return x.AccessLog
+	}
+	return nil
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
 	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
+	Outbox        *Data_Outbox           `protobuf:"bytes,3,opt,name=outbox,proto3" json:"outbox,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Data) Reset() {
 	*x = Data{}
-	mi := &file_conf_conf_proto_msgTypes[2]
+	mi := &file_conf_conf_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -149,7 +236,7 @@ func (x *Data) String() string {
 func (*Data) ProtoMessage() {}
 
 func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[2]
+	mi := &file_conf_conf_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -162,7 +249,7 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2}
+	return file_conf_conf_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Data) GetDatabase() *Data_Database {
@@ -179,6 +266,13 @@ func (x *Data) GetRedis() *Data_Redis {
 	return nil
 }
 
+func (x *Data) GetOutbox() *Data_Outbox {
+	if x != nil {
+		return x.Outbox
+	}
+	return nil
+}
+
 type Application struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -188,7 +282,7 @@ type Application struct {
 
 func (x *Application) Reset() {
 	*x = Application{}
-	mi := &file_conf_conf_proto_msgTypes[3]
+	mi := &file_conf_conf_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -200,7 +294,7 @@ func (x *Application) String() string {
 func (*Application) ProtoMessage() {}
 
 func (x *Application) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[3]
+	mi := &file_conf_conf_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -213,7 +307,7 @@ func (x *Application) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Application.ProtoReflect.Descriptor instead.
 func (*Application) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{3}
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Application) GetName() string {
@@ -223,18 +317,131 @@ func (x *Application) GetName() string {
 	return ""
 }
 
+type Log_Sampling struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tick is the sampling period, defaulting to 1s.
+	Tick *durationpb.Duration `protobuf:"bytes,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	// levels maps a level name (debug, info, warn, error) to its rule.
+	// Levels without a rule are never sampled.
+	Levels        map[string]*Log_Sampling_Rule `protobuf:"bytes,2,rep,name=levels,proto3" json:"levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log_Sampling) Reset() {
+	*x = Log_Sampling{}
+	mi := &file_conf_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log_Sampling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_Sampling) ProtoMessage() {}
+
+func (x *Log_Sampling) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log_Sampling.ProtoReflect.Descriptor instead.
+func (*Log_Sampling) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *Log_Sampling) GetTick() *durationpb.Duration {
+	if x != nil {
+		return x.Tick
+	}
+	return nil
+}
+
+func (x *Log_Sampling) GetLevels() map[string]*Log_Sampling_Rule {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+type Log_Sampling_Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// initial entries with the same level and message are logged per
+	// tick, then every thereafter-th one. A zero thereafter drops the rest.
+	Initial       int32 `protobuf:"varint,1,opt,name=initial,proto3" json:"initial,omitempty"`
+	Thereafter    int32 `protobuf:"varint,2,opt,name=thereafter,proto3" json:"thereafter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log_Sampling_Rule) Reset() {
+	*x = Log_Sampling_Rule{}
+	mi := &file_conf_conf_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log_Sampling_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log_Sampling_Rule) ProtoMessage() {}
+
+func (x *Log_Sampling_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log_Sampling_Rule.ProtoReflect.Descriptor instead.
+func (*Log_Sampling_Rule) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0, 0}
+}
+
+func (x *Log_Sampling_Rule) GetInitial() int32 {
+	if x != nil {
+		return x.Initial
+	}
+	return 0
+}
+
+func (x *Log_Sampling_Rule) GetThereafter() int32 {
+	if x != nil {
+		return x.Thereafter
+	}
+	return 0
+}
+
 type Server_HTTP struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Network string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr    string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// error_envelope writes errors as {code, reason, message, metadata, request_id}.
+	ErrorEnvelope bool `protobuf:"varint,4,opt,name=error_envelope,json=errorEnvelope,proto3" json:"error_envelope,omitempty"`
+	// data_envelope wraps successful JSON responses as {"data": ...}.
+	DataEnvelope  bool `protobuf:"varint,5,opt,name=data_envelope,json=dataEnvelope,proto3" json:"data_envelope,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -246,7 +453,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +466,7 @@ func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
 func (*Server_HTTP) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1, 0}
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
 }
 
 func (x *Server_HTTP) GetNetwork() string {
@@ -283,6 +490,20 @@ func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
+func (x *Server_HTTP) GetErrorEnvelope() bool {
+	if x != nil {
+		return x.ErrorEnvelope
+	}
+	return false
+}
+
+func (x *Server_HTTP) GetDataEnvelope() bool {
+	if x != nil {
+		return x.DataEnvelope
+	}
+	return false
+}
+
 type Server_GRPC struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
@@ -294,7 +515,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -306,7 +527,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +540,7 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
 func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{1, 1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
 }
 
 func (x *Server_GRPC) GetNetwork() string {
@@ -343,37 +564,34 @@ func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
-type Data_Database struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Username        string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password        string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	Host            string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
-	Port            int64                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
-	DbName          string                 `protobuf:"bytes,5,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	MaxIdleConns    int64                  `protobuf:"varint,6,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
-	MaxOpenConns    int64                  `protobuf:"varint,7,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
-	DbCharset       string                 `protobuf:"bytes,8,opt,name=db_charset,json=dbCharset,proto3" json:"db_charset,omitempty"`
-	ConnMaxLifetime *durationpb.Duration   `protobuf:"bytes,9,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
-	ConnMaxIdleTime *durationpb.Duration   `protobuf:"bytes,10,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type Server_Idempotency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// operations are the full names of the operations honoring the
+	// Idempotency-Key header, e.g. /helloworld.v1.Greeter/CreateGreeter.
+	Operations []string `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// ttl is how long a response is replayed, defaulting to 24h.
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// lock_ttl is how long an unfinished request blocks its key, defaulting to 1m.
+	LockTtl       *durationpb.Duration `protobuf:"bytes,3,opt,name=lock_ttl,json=lockTtl,proto3" json:"lock_ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Database) Reset() {
-	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[6]
+func (x *Server_Idempotency) Reset() {
+	*x = Server_Idempotency{}
+	mi := &file_conf_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Database) String() string {
+func (x *Server_Idempotency) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Database) ProtoMessage() {}
+func (*Server_Idempotency) ProtoMessage() {}
 
-func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[6]
+func (x *Server_Idempotency) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,97 +602,396 @@ func (x *Data_Database) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
-func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use Server_Idempotency.ProtoReflect.Descriptor instead.
+func (*Server_Idempotency) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 2}
 }
 
-func (x *Data_Database) GetUsername() string {
+func (x *Server_Idempotency) GetOperations() []string {
 	if x != nil {
-		return x.Username
+		return x.Operations
 	}
-	return ""
+	return nil
 }
 
-func (x *Data_Database) GetPassword() string {
+func (x *Server_Idempotency) GetTtl() *durationpb.Duration {
 	if x != nil {
-		return x.Password
+		return x.Ttl
 	}
-	return ""
+	return nil
 }
 
-func (x *Data_Database) GetHost() string {
+func (x *Server_Idempotency) GetLockTtl() *durationpb.Duration {
 	if x != nil {
-		return x.Host
+		return x.LockTtl
 	}
-	return ""
+	return nil
 }
 
-func (x *Data_Database) GetPort() int64 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
+type Server_Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// group is the consumer group of the service's instances, defaulting
+	// to kratos_layout.
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// consumer names the instance in the group, defaulting to its hostname
+	// and pid. Prefer a name that is stable across restarts, e.g. a
+	// StatefulSet pod name, so a restarted instance resumes its pending
+	// messages.
+	Consumer string `protobuf:"bytes,2,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	// max_attempts is how often a message is delivered before it is
+	// dead-lettered, defaulting to 5.
+	MaxAttempts int32 `protobuf:"varint,3,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// retry_delay is how long a message stays unacknowledged before it is
+	// delivered again, defaulting to 30s when unset or not positive. It must
+	// exceed the handler time.
+	RetryDelay *durationpb.Duration `protobuf:"bytes,4,opt,name=retry_delay,json=retryDelay,proto3" json:"retry_delay,omitempty"`
+	// batch_size is the most messages of a topic received at once,
+	// defaulting to 10.
+	BatchSize     int32 `protobuf:"varint,5,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Database) GetDbName() string {
+func (x *Server_Event) Reset() {
+	*x = Server_Event{}
+	mi := &file_conf_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Event) ProtoMessage() {}
+
+func (x *Server_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[11]
 	if x != nil {
-		return x.DbName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Data_Database) GetMaxIdleConns() int64 {
+// Deprecated: Use Server_Event.ProtoReflect.Descriptor instead.
+func (*Server_Event) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Server_Event) GetGroup() string {
 	if x != nil {
-		return x.MaxIdleConns
+		return x.Group
 	}
-	return 0
+	return ""
 }
 
-func (x *Data_Database) GetMaxOpenConns() int64 {
+func (x *Server_Event) GetConsumer() string {
 	if x != nil {
-		return x.MaxOpenConns
+		return x.Consumer
 	}
-	return 0
+	return ""
 }
 
-func (x *Data_Database) GetDbCharset() string {
+func (x *Server_Event) GetMaxAttempts() int32 {
 	if x != nil {
-		return x.DbCharset
+		return x.MaxAttempts
 	}
-	return ""
+	return 0
 }
 
-func (x *Data_Database) GetConnMaxLifetime() *durationpb.Duration {
+func (x *Server_Event) GetRetryDelay() *durationpb.Duration {
 	if x != nil {
-		return x.ConnMaxLifetime
+		return x.RetryDelay
 	}
 	return nil
 }
 
-func (x *Data_Database) GetConnMaxIdleTime() *durationpb.Duration {
+func (x *Server_Event) GetBatchSize() int32 {
 	if x != nil {
-		return x.ConnMaxIdleTime
+		return x.BatchSize
 	}
-	return nil
+	return 0
 }
 
-type Data_Redis struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	Db            int32                  `protobuf:"varint,4,opt,name=db,proto3" json:"db,omitempty"`
-	DialTimeout   *durationpb.Duration   `protobuf:"bytes,5,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`
-	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,6,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
-	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,7,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+type Server_AccessLog struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// max_dump_size is the size in bytes of the longest request or reply
+	// dump, defaulting to 4096. Longer dumps are truncated.
+	MaxDumpSize   int32 `protobuf:"varint,1,opt,name=max_dump_size,json=maxDumpSize,proto3" json:"max_dump_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Redis) Reset() {
+func (x *Server_AccessLog) Reset() {
+	*x = Server_AccessLog{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_AccessLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_AccessLog) ProtoMessage() {}
+
+func (x *Server_AccessLog) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_AccessLog.ProtoReflect.Descriptor instead.
+func (*Server_AccessLog) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *Server_AccessLog) GetMaxDumpSize() int32 {
+	if x != nil {
+		return x.MaxDumpSize
+	}
+	return 0
+}
+
+type Server_Principal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// header is a header carrying the ID of the caller, set by a trusted
+	// gateway after authentication, e.g. X-Principal-ID. When empty, no
+	// header is trusted.
+	Header        string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Principal) Reset() {
+	*x = Server_Principal{}
+	mi := &file_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Principal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Principal) ProtoMessage() {}
+
+func (x *Server_Principal) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Principal.ProtoReflect.Descriptor instead.
+func (*Server_Principal) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *Server_Principal) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+type Data_Database struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Username        string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password        string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Host            string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Port            int64                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	DbName          string                 `protobuf:"bytes,5,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	MaxIdleConns    int64                  `protobuf:"varint,6,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	MaxOpenConns    int64                  `protobuf:"varint,7,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	DbCharset       string                 `protobuf:"bytes,8,opt,name=db_charset,json=dbCharset,proto3" json:"db_charset,omitempty"`
+	ConnMaxLifetime *durationpb.Duration   `protobuf:"bytes,9,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
+	ConnMaxIdleTime *durationpb.Duration   `protobuf:"bytes,10,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"`
+	// replicas serve reads; writes and transactions always go to the primary.
+	Replicas                   []*Data_Database_Replica `protobuf:"bytes,11,rep,name=replicas,proto3" json:"replicas,omitempty"`
+	ReplicaHealthCheckInterval *durationpb.Duration     `protobuf:"bytes,12,opt,name=replica_health_check_interval,json=replicaHealthCheckInterval,proto3" json:"replica_health_check_interval,omitempty"`
+	// driver is mysql, postgres or sqlite, defaulting to mysql.
+	// For sqlite, db_name is the database file path or ":memory:".
+	Driver string `protobuf:"bytes,13,opt,name=driver,proto3" json:"driver,omitempty"`
+	// ssl_mode is the postgres sslmode, defaulting to disable.
+	SslMode       string `protobuf:"bytes,14,opt,name=ssl_mode,json=sslMode,proto3" json:"ssl_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Database) Reset() {
+	*x = Data_Database{}
+	mi := &file_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database) ProtoMessage() {}
+
+func (x *Data_Database) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
+func (*Data_Database) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Data_Database) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Data_Database) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Data_Database) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Data_Database) GetPort() int64 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Data_Database) GetDbName() string {
+	if x != nil {
+		return x.DbName
+	}
+	return ""
+}
+
+func (x *Data_Database) GetMaxIdleConns() int64 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetMaxOpenConns() int64 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetDbCharset() string {
+	if x != nil {
+		return x.DbCharset
+	}
+	return ""
+}
+
+func (x *Data_Database) GetConnMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxLifetime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetConnMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxIdleTime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicas() []*Data_Database_Replica {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicaHealthCheckInterval() *durationpb.Duration {
+	if x != nil {
+		return x.ReplicaHealthCheckInterval
+	}
+	return nil
+}
+
+func (x *Data_Database) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_Database) GetSslMode() string {
+	if x != nil {
+		return x.SslMode
+	}
+	return ""
+}
+
+type Data_Redis struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// network and addr are used in single mode only.
+	Network  string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr     string `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	// db is ignored in cluster mode.
+	Db           int32                `protobuf:"varint,4,opt,name=db,proto3" json:"db,omitempty"`
+	DialTimeout  *durationpb.Duration `protobuf:"bytes,5,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`
+	ReadTimeout  *durationpb.Duration `protobuf:"bytes,6,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	WriteTimeout *durationpb.Duration `protobuf:"bytes,7,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	// mode is single, sentinel or cluster, defaulting to single.
+	Mode string `protobuf:"bytes,8,opt,name=mode,proto3" json:"mode,omitempty"`
+	// master_name and sentinel_addrs are required in sentinel mode.
+	MasterName       string   `protobuf:"bytes,9,opt,name=master_name,json=masterName,proto3" json:"master_name,omitempty"`
+	SentinelAddrs    []string `protobuf:"bytes,10,rep,name=sentinel_addrs,json=sentinelAddrs,proto3" json:"sentinel_addrs,omitempty"`
+	SentinelPassword string   `protobuf:"bytes,11,opt,name=sentinel_password,json=sentinelPassword,proto3" json:"sentinel_password,omitempty"`
+	// cluster_addrs are the seed nodes in cluster mode.
+	ClusterAddrs []string `protobuf:"bytes,12,rep,name=cluster_addrs,json=clusterAddrs,proto3" json:"cluster_addrs,omitempty"`
+	Username     string   `protobuf:"bytes,13,opt,name=username,proto3" json:"username,omitempty"`
+	// pool_size defaults to 10 connections per CPU.
+	PoolSize      int32           `protobuf:"varint,14,opt,name=pool_size,json=poolSize,proto3" json:"pool_size,omitempty"`
+	Tls           *Data_Redis_TLS `protobuf:"bytes,15,opt,name=tls,proto3" json:"tls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Redis) Reset() {
 	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +1003,7 @@ func (x *Data_Redis) String() string {
 func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +1016,7 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
 func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{2, 1}
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1}
 }
 
 func (x *Data_Redis) GetNetwork() string {
@@ -551,29 +1068,370 @@ func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
 	return nil
 }
 
+func (x *Data_Redis) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetMasterName() string {
+	if x != nil {
+		return x.MasterName
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetSentinelAddrs() []string {
+	if x != nil {
+		return x.SentinelAddrs
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetSentinelPassword() string {
+	if x != nil {
+		return x.SentinelPassword
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetClusterAddrs() []string {
+	if x != nil {
+		return x.ClusterAddrs
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetPoolSize() int32 {
+	if x != nil {
+		return x.PoolSize
+	}
+	return 0
+}
+
+func (x *Data_Redis) GetTls() *Data_Redis_TLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+type Data_Outbox struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// interval is how often the relay polls the outbox, defaulting to 1s.
+	Interval *durationpb.Duration `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	// batch_size is the most events relayed per poll, defaulting to 100.
+	BatchSize int32 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// retry_backoff is the delay before retrying a failed event, defaulting
+	// to 1s. It doubles on every failure up to max_retry_backoff (5m).
+	RetryBackoff    *durationpb.Duration `protobuf:"bytes,3,opt,name=retry_backoff,json=retryBackoff,proto3" json:"retry_backoff,omitempty"`
+	MaxRetryBackoff *durationpb.Duration `protobuf:"bytes,4,opt,name=max_retry_backoff,json=maxRetryBackoff,proto3" json:"max_retry_backoff,omitempty"`
+	// retention is how long published events are kept, defaulting to 24h.
+	Retention *durationpb.Duration `protobuf:"bytes,5,opt,name=retention,proto3" json:"retention,omitempty"`
+	// stream_max_len trims the Redis streams to about this many entries.
+	// Streams are not trimmed when it is 0.
+	StreamMaxLen  int64 `protobuf:"varint,6,opt,name=stream_max_len,json=streamMaxLen,proto3" json:"stream_max_len,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Outbox) Reset() {
+	*x = Data_Outbox{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Outbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Outbox) ProtoMessage() {}
+
+func (x *Data_Outbox) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Outbox.ProtoReflect.Descriptor instead.
+func (*Data_Outbox) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Data_Outbox) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Data_Outbox) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Data_Outbox) GetRetryBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.RetryBackoff
+	}
+	return nil
+}
+
+func (x *Data_Outbox) GetMaxRetryBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxRetryBackoff
+	}
+	return nil
+}
+
+func (x *Data_Outbox) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Data_Outbox) GetStreamMaxLen() int64 {
+	if x != nil {
+		return x.StreamMaxLen
+	}
+	return 0
+}
+
+type Data_Database_Replica struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Host  string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port  int64                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// username and password default to the primary's when empty.
+	Username      string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Database_Replica) Reset() {
+	*x = Data_Database_Replica{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database_Replica) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database_Replica) ProtoMessage() {}
+
+func (x *Data_Database_Replica) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database_Replica.ProtoReflect.Descriptor instead.
+func (*Data_Database_Replica) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 0, 0}
+}
+
+func (x *Data_Database_Replica) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Data_Database_Replica) GetPort() int64 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Data_Database_Replica) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Data_Database_Replica) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type Data_Redis_TLS struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// ca_file verifies the server; cert_file and key_file enable client certificates.
+	CaFile             string `protobuf:"bytes,2,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	CertFile           string `protobuf:"bytes,3,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	KeyFile            string `protobuf:"bytes,4,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	ServerName         string `protobuf:"bytes,5,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	InsecureSkipVerify bool   `protobuf:"varint,6,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Data_Redis_TLS) Reset() {
+	*x = Data_Redis_TLS{}
+	mi := &file_conf_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Redis_TLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Redis_TLS) ProtoMessage() {}
+
+func (x *Data_Redis_TLS) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Redis_TLS.ProtoReflect.Descriptor instead.
+func (*Data_Redis_TLS) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{3, 1, 0}
+}
+
+func (x *Data_Redis_TLS) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Data_Redis_TLS) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *Data_Redis_TLS) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
 var File_conf_conf_proto protoreflect.FileDescriptor
 
 const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"]\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\x80\x01\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
-	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\"\xb8\x02\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12!\n" +
+	"\x03log\x18\x03 \x01(\v2\x0f.kratos.api.LogR\x03log\"\xd1\x02\n" +
+	"\x03Log\x124\n" +
+	"\bsampling\x18\x01 \x01(\v2\x18.kratos.api.Log.SamplingR\bsampling\x1a\x93\x02\n" +
+	"\bSampling\x12-\n" +
+	"\x04tick\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x04tick\x12<\n" +
+	"\x06levels\x18\x02 \x03(\v2$.kratos.api.Log.Sampling.LevelsEntryR\x06levels\x1a@\n" +
+	"\x04Rule\x12\x18\n" +
+	"\ainitial\x18\x01 \x01(\x05R\ainitial\x12\x1e\n" +
+	"\n" +
+	"thereafter\x18\x02 \x01(\x05R\n" +
+	"thereafter\x1aX\n" +
+	"\vLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x123\n" +
+	"\x05value\x18\x02 \x01(\v2\x1d.kratos.api.Log.Sampling.RuleR\x05value:\x028\x01\"\x93\b\n" +
 	"\x06Server\x12+\n" +
 	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
-	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
+	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x12@\n" +
+	"\vidempotency\x18\x03 \x01(\v2\x1e.kratos.api.Server.IdempotencyR\vidempotency\x12.\n" +
+	"\x05event\x18\x04 \x01(\v2\x18.kratos.api.Server.EventR\x05event\x12:\n" +
+	"\tprincipal\x18\x05 \x01(\v2\x1c.kratos.api.Server.PrincipalR\tprincipal\x12;\n" +
+	"\n" +
+	"access_log\x18\x06 \x01(\v2\x1c.kratos.api.Server.AccessLogR\taccessLog\x1a\xb5\x01\n" +
 	"\x04HTTP\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1ai\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12%\n" +
+	"\x0eerror_envelope\x18\x04 \x01(\bR\rerrorEnvelope\x12#\n" +
+	"\rdata_envelope\x18\x05 \x01(\bR\fdataEnvelope\x1ai\n" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\x8b\x06\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\x90\x01\n" +
+	"\vIdempotency\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\tR\n" +
+	"operations\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x124\n" +
+	"\block_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\alockTtl\x1a\xb7\x01\n" +
+	"\x05Event\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x1a\n" +
+	"\bconsumer\x18\x02 \x01(\tR\bconsumer\x12!\n" +
+	"\fmax_attempts\x18\x03 \x01(\x05R\vmaxAttempts\x12:\n" +
+	"\vretry_delay\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"retryDelay\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x05 \x01(\x05R\tbatchSize\x1a/\n" +
+	"\tAccessLog\x12\"\n" +
+	"\rmax_dump_size\x18\x01 \x01(\x05R\vmaxDumpSize\x1a#\n" +
+	"\tPrincipal\x12\x16\n" +
+	"\x06header\x18\x01 \x01(\tR\x06header\"\x99\x0f\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
-	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a\xfd\x02\n" +
+	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12/\n" +
+	"\x06outbox\x18\x03 \x01(\v2\x17.kratos.api.Data.OutboxR\x06outbox\x1a\xb8\x05\n" +
 	"\bDatabase\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
@@ -586,7 +1444,16 @@ const file_conf_conf_proto_rawDesc = "" +
 	"db_charset\x18\b \x01(\tR\tdbCharset\x12E\n" +
 	"\x11conn_max_lifetime\x18\t \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxLifetime\x12F\n" +
 	"\x12conn_max_idle_time\x18\n" +
-	" \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxIdleTime\x1a\x9d\x02\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxIdleTime\x12=\n" +
+	"\breplicas\x18\v \x03(\v2!.kratos.api.Data.Database.ReplicaR\breplicas\x12\\\n" +
+	"\x1dreplica_health_check_interval\x18\f \x01(\v2\x19.google.protobuf.DurationR\x1areplicaHealthCheckInterval\x12\x16\n" +
+	"\x06driver\x18\r \x01(\tR\x06driver\x12\x19\n" +
+	"\bssl_mode\x18\x0e \x01(\tR\asslMode\x1ai\n" +
+	"\aReplica\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x03R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x1a\xf8\x05\n" +
 	"\x05Redis\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x1a\n" +
@@ -594,7 +1461,33 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x02db\x18\x04 \x01(\x05R\x02db\x12<\n" +
 	"\fdial_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\vdialTimeout\x12<\n" +
 	"\fread_timeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\"!\n" +
+	"\rwrite_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x12\x12\n" +
+	"\x04mode\x18\b \x01(\tR\x04mode\x12\x1f\n" +
+	"\vmaster_name\x18\t \x01(\tR\n" +
+	"masterName\x12%\n" +
+	"\x0esentinel_addrs\x18\n" +
+	" \x03(\tR\rsentinelAddrs\x12+\n" +
+	"\x11sentinel_password\x18\v \x01(\tR\x10sentinelPassword\x12#\n" +
+	"\rcluster_addrs\x18\f \x03(\tR\fclusterAddrs\x12\x1a\n" +
+	"\busername\x18\r \x01(\tR\busername\x12\x1b\n" +
+	"\tpool_size\x18\x0e \x01(\x05R\bpoolSize\x12,\n" +
+	"\x03tls\x18\x0f \x01(\v2\x1a.kratos.api.Data.Redis.TLSR\x03tls\x1a\xc3\x01\n" +
+	"\x03TLS\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x17\n" +
+	"\aca_file\x18\x02 \x01(\tR\x06caFile\x12\x1b\n" +
+	"\tcert_file\x18\x03 \x01(\tR\bcertFile\x12\x19\n" +
+	"\bkey_file\x18\x04 \x01(\tR\akeyFile\x12\x1f\n" +
+	"\vserver_name\x18\x05 \x01(\tR\n" +
+	"serverName\x120\n" +
+	"\x14insecure_skip_verify\x18\x06 \x01(\bR\x12insecureSkipVerify\x1a\xc4\x02\n" +
+	"\x06Outbox\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12>\n" +
+	"\rretry_backoff\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fretryBackoff\x12E\n" +
+	"\x11max_retry_backoff\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0fmaxRetryBackoff\x127\n" +
+	"\tretention\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\tretention\x12$\n" +
+	"\x0estream_max_len\x18\x06 \x01(\x03R\fstreamMaxLen\"!\n" +
 	"\vApplication\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04nameB7Z5github.com/go-kratos/kratos-layout/internal/conf;confb\x06proto3"
 
@@ -610,37 +1503,68 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_conf_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*Server)(nil),              // 1: kratos.api.Server
-	(*Data)(nil),                // 2: kratos.api.Data
-	(*Application)(nil),         // 3: kratos.api.Application
-	(*Server_HTTP)(nil),         // 4: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 5: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 6: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 7: kratos.api.Data.Redis
-	(*durationpb.Duration)(nil), // 8: google.protobuf.Duration
+	(*Bootstrap)(nil),             // 0: kratos.api.Bootstrap
+	(*Log)(nil),                   // 1: kratos.api.Log
+	(*Server)(nil),                // 2: kratos.api.Server
+	(*Data)(nil),                  // 3: kratos.api.Data
+	(*Application)(nil),           // 4: kratos.api.Application
+	(*Log_Sampling)(nil),          // 5: kratos.api.Log.Sampling
+	(*Log_Sampling_Rule)(nil),     // 6: kratos.api.Log.Sampling.Rule
+	nil,                           // 7: kratos.api.Log.Sampling.LevelsEntry
+	(*Server_HTTP)(nil),           // 8: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),           // 9: kratos.api.Server.GRPC
+	(*Server_Idempotency)(nil),    // 10: kratos.api.Server.Idempotency
+	(*Server_Event)(nil),          // 11: kratos.api.Server.Event
+	(*Server_AccessLog)(nil),      // 12: kratos.api.Server.AccessLog
+	(*Server_Principal)(nil),      // 13: kratos.api.Server.Principal
+	(*Data_Database)(nil),         // 14: kratos.api.Data.Database
+	(*Data_Redis)(nil),            // 15: kratos.api.Data.Redis
+	(*Data_Outbox)(nil),           // 16: kratos.api.Data.Outbox
+	(*Data_Database_Replica)(nil), // 17: kratos.api.Data.Database.Replica
+	(*Data_Redis_TLS)(nil),        // 18: kratos.api.Data.Redis.TLS
+	(*durationpb.Duration)(nil),   // 19: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
-	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
-	2,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	4,  // 2: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	5,  // 3: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	6,  // 4: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	7,  // 5: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	8,  // 6: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	8,  // 7: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	8,  // 8: kratos.api.Data.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
-	8,  // 9: kratos.api.Data.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
-	8,  // 10: kratos.api.Data.Redis.dial_timeout:type_name -> google.protobuf.Duration
-	8,  // 11: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	8,  // 12: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	2,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
+	3,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
+	1,  // 2: kratos.api.Bootstrap.log:type_name -> kratos.api.Log
+	5,  // 3: kratos.api.Log.sampling:type_name -> kratos.api.Log.Sampling
+	8,  // 4: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	9,  // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	10, // 6: kratos.api.Server.idempotency:type_name -> kratos.api.Server.Idempotency
+	11, // 7: kratos.api.Server.event:type_name -> kratos.api.Server.Event
+	13, // 8: kratos.api.Server.principal:type_name -> kratos.api.Server.Principal
+	12, // 9: kratos.api.Server.access_log:type_name -> kratos.api.Server.AccessLog
+	14, // 10: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	15, // 11: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	16, // 12: kratos.api.Data.outbox:type_name -> kratos.api.Data.Outbox
+	19, // 13: kratos.api.Log.Sampling.tick:type_name -> google.protobuf.Duration
+	7,  // 14: kratos.api.Log.Sampling.levels:type_name -> kratos.api.Log.Sampling.LevelsEntry
+	6,  // 15: kratos.api.Log.Sampling.LevelsEntry.value:type_name -> kratos.api.Log.Sampling.Rule
+	19, // 16: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	19, // 17: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	19, // 18: kratos.api.Server.Idempotency.ttl:type_name -> google.protobuf.Duration
+	19, // 19: kratos.api.Server.Idempotency.lock_ttl:type_name -> google.protobuf.Duration
+	19, // 20: kratos.api.Server.Event.retry_delay:type_name -> google.protobuf.Duration
+	19, // 21: kratos.api.Data.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
+	19, // 22: kratos.api.Data.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
+	17, // 23: kratos.api.Data.Database.replicas:type_name -> kratos.api.Data.Database.Replica
+	19, // 24: kratos.api.Data.Database.replica_health_check_interval:type_name -> google.protobuf.Duration
+	19, // 25: kratos.api.Data.Redis.dial_timeout:type_name -> google.protobuf.Duration
+	19, // 26: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	19, // 27: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	18, // 28: kratos.api.Data.Redis.tls:type_name -> kratos.api.Data.Redis.TLS
+	19, // 29: kratos.api.Data.Outbox.interval:type_name -> google.protobuf.Duration
+	19, // 30: kratos.api.Data.Outbox.retry_backoff:type_name -> google.protobuf.Duration
+	19, // 31: kratos.api.Data.Outbox.max_retry_backoff:type_name -> google.protobuf.Duration
+	19, // 32: kratos.api.Data.Outbox.retention:type_name -> google.protobuf.Duration
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -654,7 +1578,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,265 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+)
+
+// Results recorded on the cache requests metric.
+const (
+	CacheResultHit         = "hit"
+	CacheResultNegativeHit = "negative_hit"
+	CacheResultMiss        = "miss"
+)
+
+// MetricCacheRequests is the name of the counter of cache lookups.
+const MetricCacheRequests = "cache_requests_total"
+
+// notFoundMarker is stored for negatively cached keys. JSON never starts with it.
+const notFoundMarker = "\x00not_found"
+
+// leaseMarker prefixes the lease a loading reader holds on a missing key,
+// see Get. JSON never starts with it.
+const leaseMarker = "\x00lease:"
+
+// cacheLeaseTTL bounds how long a load may take and still cache its value.
+const cacheLeaseTTL = 10 * time.Second
+
+// storeScript sets KEYS[1] to ARGV[2] for ARGV[3] milliseconds if it still
+// holds the lease ARGV[1].
+var storeScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+end
+return 0
+`)
+
+var (
+	cacheOnce    sync.Once
+	cacheCounter metric.Int64Counter
+)
+
+// recordCacheRequest increments the cache requests counter.
+func recordCacheRequest(ctx context.Context, name, result string) {
+	cacheOnce.Do(func() {
+		var err error
+		cacheCounter, err = otel.Meter("github.com/go-kratos/kratos-layout/internal/data").Int64Counter(
+			MetricCacheRequests,
+			metric.WithDescription("The total number of cache lookups by result."),
+		)
+		if err != nil {
+			otel.Handle(err)
+		}
+	})
+	if cacheCounter == nil {
+		return
+	}
+	cacheCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cache", name),
+		attribute.String("result", result),
+	))
+}
+
+// CacheOption is Cache option.
+type CacheOption func(*cacheOptions)
+
+type cacheOptions struct {
+	ttl         time.Duration
+	jitter      float64
+	notFound    error
+	negativeTTL time.Duration
+}
+
+// WithCacheTTL sets how long loaded values are cached, defaulting to 5 minutes.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithCacheJitter adds a random fraction of up to jitter of the TTL to every
+// entry, so that entries filled together do not expire together. Defaults to 0.1.
+func WithCacheJitter(jitter float64) CacheOption {
+	return func(o *cacheOptions) {
+		o.jitter = jitter
+	}
+}
+
+// WithNegativeCache caches loads failing with err, matched by errors.Is, for
+// ttl and returns err for them until then.
+func WithNegativeCache(err error, ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.notFound = err
+		o.negativeTTL = ttl
+	}
+}
+
+// Cache is a cache-aside helper storing JSON encoded values of T in Redis.
+// Concurrent misses of a key share one load. Redis errors are logged and
+// fall back to the loader, so the cache never fails a read by itself.
+//
+// A miss leases the key before it loads, and only caches the loaded value
+// while it still holds the lease. Delete revokes the lease, so a load that
+// read a value before a write cannot cache it after the write's Delete.
+type Cache[T any] struct {
+	rdb   redis.UniversalClient
+	name  string
+	opts  cacheOptions
+	group singleflight.Group
+	log   *log.Helper
+}
+
+// NewCache creates a Cache whose keys are prefixed with name.
+func NewCache[T any](rdb redis.UniversalClient, name string, logger log.Logger, opts ...CacheOption) *Cache[T] {
+	o := cacheOptions{
+		ttl:    5 * time.Minute,
+		jitter: 0.1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Cache[T]{
+		rdb:  rdb,
+		name: name,
+		opts: o,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// Get returns the cached value of key, or calls load and caches its result.
+func (c *Cache[T]) Get(ctx context.Context, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	fullKey := c.key(key)
+
+	raw, err := c.rdb.Get(ctx, fullKey).Result()
+	switch {
+	case err == nil && strings.HasPrefix(raw, leaseMarker):
+		// Another reader is loading the key.
+	case err == nil && raw == notFoundMarker && c.opts.notFound != nil:
+		recordCacheRequest(ctx, c.name, CacheResultNegativeHit)
+		return zero, c.opts.notFound
+	case err == nil:
+		var v T
+		if err := json.Unmarshal([]byte(raw), &v); err == nil {
+			recordCacheRequest(ctx, c.name, CacheResultHit)
+			return v, nil
+		}
+		c.log.WithContext(ctx).Warnf("cache %s: decode %s failed, reloading: %v", c.name, fullKey, err)
+		// Free the key for the lease of the reload.
+		if err := c.rdb.Del(ctx, fullKey).Err(); err != nil {
+			c.log.WithContext(ctx).Warnf("cache %s: delete %s failed: %v", c.name, fullKey, err)
+		}
+	case !errors.Is(err, redis.Nil):
+		c.log.WithContext(ctx).Warnf("cache %s: get %s failed: %v", c.name, fullKey, err)
+	}
+	recordCacheRequest(ctx, c.name, CacheResultMiss)
+
+	// The shared load must not be canceled by whichever caller started it.
+	ch := c.group.DoChan(fullKey, func() (any, error) {
+		loadCtx := context.WithoutCancel(ctx)
+		lease := c.lease(loadCtx, fullKey)
+		v, err := load(loadCtx)
+		if lease != "" {
+			c.store(loadCtx, fullKey, lease, v, err)
+		}
+		return v, err
+	})
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
+
+// Delete invalidates keys. Each key is deleted by its own command, so that
+// keys in different cluster slots can be deleted together.
+func (c *Cache[T]) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := c.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, c.key(key))
+		}
+		return nil
+	})
+	return err
+}
+
+// lease reserves the missing key fullKey for a load, and returns the lease,
+// or "" when another reader holds a lease on it.
+func (c *Cache[T]) lease(ctx context.Context, fullKey string) string {
+	lease := leaseMarker + strconv.FormatUint(rand.Uint64(), 36)
+	ok, err := c.rdb.SetNX(ctx, fullKey, lease, cacheLeaseTTL).Result()
+	if err != nil {
+		c.log.WithContext(ctx).Warnf("cache %s: lease %s failed: %v", c.name, fullKey, err)
+		return ""
+	}
+	if !ok {
+		return ""
+	}
+	return lease
+}
+
+// store caches the result of a load of fullKey, if the key still holds lease.
+func (c *Cache[T]) store(ctx context.Context, fullKey, lease string, v T, loadErr error) {
+	var (
+		value any
+		ttl   time.Duration
+	)
+	switch {
+	case loadErr == nil:
+		b, err := json.Marshal(v)
+		if err != nil {
+			c.log.WithContext(ctx).Warnf("cache %s: encode %s failed: %v", c.name, fullKey, err)
+			c.release(ctx, fullKey, lease)
+			return
+		}
+		value, ttl = b, c.withJitter(c.opts.ttl)
+	case c.opts.notFound != nil && errors.Is(loadErr, c.opts.notFound):
+		value, ttl = notFoundMarker, c.withJitter(c.opts.negativeTTL)
+	default:
+		c.release(ctx, fullKey, lease)
+		return
+	}
+
+	if err := storeScript.Run(ctx, c.rdb, []string{fullKey}, lease, value, ttl.Milliseconds()).Err(); err != nil {
+		c.log.WithContext(ctx).Warnf("cache %s: set %s failed: %v", c.name, fullKey, err)
+	}
+}
+
+// release deletes the lease of a load that caches nothing, so that the next
+// miss can lease the key.
+func (c *Cache[T]) release(ctx context.Context, fullKey, lease string) {
+	if err := releaseScript.Run(ctx, c.rdb, []string{fullKey}, lease).Err(); err != nil {
+		c.log.WithContext(ctx).Warnf("cache %s: release %s failed: %v", c.name, fullKey, err)
+	}
+}
+
+func (c *Cache[T]) withJitter(ttl time.Duration) time.Duration {
+	if c.opts.jitter <= 0 || ttl <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration(rand.Int64N(int64(float64(ttl)*c.opts.jitter)+1))
+}
+
+func (c *Cache[T]) key(key string) string {
+	return c.name + ":" + key
+}
@@ -0,0 +1,231 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNotFound = errors.New("not found")
+
+func newTestCache(t *testing.T, opts ...CacheOption) (*Cache[string], *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return NewCache[string](rdb, "test", log.DefaultLogger, opts...), mr
+}
+
+// countingLoader returns a loader that counts its calls and returns v and err.
+func countingLoader(calls *atomic.Int32, v string, err error) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		calls.Add(1)
+		return v, err
+	}
+}
+
+func TestCache_Get(t *testing.T) {
+	c, mr := newTestCache(t, WithCacheTTL(time.Minute), WithCacheJitter(0))
+	ctx := context.Background()
+	var calls atomic.Int32
+
+	for range 3 {
+		v, err := c.Get(ctx, "k", countingLoader(&calls, "v", nil))
+		require.NoError(t, err)
+		require.Equal(t, "v", v)
+	}
+	require.Equal(t, int32(1), calls.Load())
+	require.Equal(t, time.Minute, mr.TTL("test:k"))
+
+	require.NoError(t, c.Delete(ctx, "k"))
+	_, err := c.Get(ctx, "k", countingLoader(&calls, "v", nil))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestCache_DeleteDuringLoad(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+
+	// A write invalidates the key while the value it replaces is loaded.
+	v, err := c.Get(ctx, "k", func(ctx context.Context) (string, error) {
+		require.NoError(t, c.Delete(ctx, "k"))
+		return "old", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "old", v)
+	require.False(t, mr.Exists("test:k"))
+
+	var calls atomic.Int32
+	v, err = c.Get(ctx, "k", countingLoader(&calls, "new", nil))
+	require.NoError(t, err)
+	require.Equal(t, "new", v)
+	require.Equal(t, int32(1), calls.Load())
+	got, err := mr.Get("test:k")
+	require.NoError(t, err)
+	require.Equal(t, `"new"`, got)
+}
+
+func TestCache_LoadErrorReleasesLease(t *testing.T) {
+	c, mr := newTestCache(t)
+	ctx := context.Background()
+
+	errBoom := errors.New("boom")
+	_, err := c.Get(ctx, "k", func(ctx context.Context) (string, error) {
+		require.True(t, mr.Exists("test:k"))
+		return "", errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+	require.False(t, mr.Exists("test:k"))
+}
+
+func TestCache_Jitter(t *testing.T) {
+	c, mr := newTestCache(t, WithCacheTTL(time.Minute), WithCacheJitter(0.5))
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	for _, key := range []string{"a", "b", "c", "d"} {
+		_, err := c.Get(ctx, key, countingLoader(&calls, "v", nil))
+		require.NoError(t, err)
+		ttl := mr.TTL("test:" + key)
+		require.GreaterOrEqual(t, ttl, time.Minute)
+		require.LessOrEqual(t, ttl, 90*time.Second)
+	}
+}
+
+func TestCache_NegativeCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []CacheOption
+		loadErr   error
+		wantCalls int32
+	}{
+		{
+			name:      "not found is cached",
+			opts:      []CacheOption{WithNegativeCache(errTestNotFound, time.Second)},
+			loadErr:   errTestNotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "wrapped not found is cached",
+			opts:      []CacheOption{WithNegativeCache(errTestNotFound, time.Second)},
+			loadErr:   errors.Join(errors.New("query"), errTestNotFound),
+			wantCalls: 1,
+		},
+		{
+			name:      "not found without negative caching",
+			loadErr:   errTestNotFound,
+			wantCalls: 2,
+		},
+		{
+			name:      "other errors are not cached",
+			opts:      []CacheOption{WithNegativeCache(errTestNotFound, time.Second)},
+			loadErr:   errors.New("boom"),
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestCache(t, tt.opts...)
+			var calls atomic.Int32
+			for range 2 {
+				_, err := c.Get(context.Background(), "k", countingLoader(&calls, "", tt.loadErr))
+				require.Error(t, err)
+			}
+			require.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestCache_NegativeCacheExpires(t *testing.T) {
+	c, mr := newTestCache(t, WithNegativeCache(errTestNotFound, time.Second), WithCacheJitter(0))
+	ctx := context.Background()
+	var calls atomic.Int32
+
+	_, err := c.Get(ctx, "k", countingLoader(&calls, "", errTestNotFound))
+	require.ErrorIs(t, err, errTestNotFound)
+
+	mr.FastForward(2 * time.Second)
+	v, err := c.Get(ctx, "k", countingLoader(&calls, "v", nil))
+	require.NoError(t, err)
+	require.Equal(t, "v", v)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestCache_Singleflight(t *testing.T) {
+	c, _ := newTestCache(t)
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	load := func(context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "v", nil
+	}
+
+	const n = 10
+	results := make(chan string, n)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Get(context.Background(), "k", load)
+			require.NoError(t, err)
+			results <- v
+		}()
+	}
+	// Let every goroutine miss and join the in-flight load before it completes.
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	require.Equal(t, int32(1), calls.Load())
+	for v := range results {
+		require.Equal(t, "v", v)
+	}
+}
+
+func TestCache_CallerCanceled(t *testing.T) {
+	c, mr := newTestCache(t)
+	release := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		<-release
+		return "v", ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Get(ctx, "k", load)
+		done <- err
+	}()
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	// The shared load still completes and fills the cache.
+	close(release)
+	require.Eventually(t, func() bool { return mr.Exists("test:k") }, time.Second, time.Millisecond)
+}
+
+func TestCache_RedisDown(t *testing.T) {
+	c, mr := newTestCache(t)
+	mr.Close()
+
+	var calls atomic.Int32
+	v, err := c.Get(context.Background(), "k", countingLoader(&calls, "v", nil))
+	require.NoError(t, err)
+	require.Equal(t, "v", v)
+	require.Error(t, c.Delete(context.Background(), "k"))
+}
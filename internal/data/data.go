@@ -15,50 +15,37 @@ import (
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewGreeterRepo)
+var ProviderSet = wire.NewSet(NewData, NewTransaction, NewLocker, NewIdempotencyStore, NewGreeterRepo,
+	NewEventPublisher, NewBroker, NewOutboxRelay, NewSubscriber)
 
 // Data is the data layer dependency container.
 type Data struct {
 	db  *gorm.DB
-	rdb *redis.Client
+	rdb redis.UniversalClient
 }
 
 // NewData creates a new Data instance and returns a cleanup function.
 func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 	logHelper := log.NewHelper(logger)
 
-	dbConf := &orm.DBConfig{
-		Username:        c.Database.Username,
-		Password:        c.Database.Password,
-		Host:            c.Database.Host,
-		Port:            fmt.Sprintf("%d", c.Database.Port),
-		DBName:          c.Database.DbName,
-		MaxIdleConns:    int(c.Database.MaxIdleConns),
-		MaxOpenConns:    int(c.Database.MaxOpenConns),
-		DBCharset:       c.Database.DbCharset,
-		ConnMaxLifetime: c.Database.ConnMaxLifetime.AsDuration(),
-		ConnMaxIdleTime: c.Database.ConnMaxIdleTime.AsDuration(),
+	ormDB, err := orm.MakeDB(NewDBConfig(c.Database))
+	if err != nil {
+		return nil, nil, err
 	}
 
-	ormDB, err := orm.MakeDB(dbConf)
+	rdb, err := NewRedisClient(c.Redis)
 	if err != nil {
+		_ = ormDB.Close()
 		return nil, nil, err
 	}
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr:         c.Redis.Addr,
-		Password:     c.Redis.Password,
-		DB:           int(c.Redis.Db),
-		DialTimeout:  c.Redis.DialTimeout.AsDuration(),
-		WriteTimeout: c.Redis.WriteTimeout.AsDuration(),
-		ReadTimeout:  c.Redis.ReadTimeout.AsDuration(),
-	})
-
 	// add redis ping check
 	pingTimeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if _, err := rdb.Ping(pingTimeoutCtx).Result(); err != nil {
 		logHelper.Errorf("failed to ping redis: %v", err)
+		_ = rdb.Close()
+		_ = ormDB.Close()
 		return nil, nil, err
 	}
 
@@ -79,3 +66,40 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
 		rdb: rdb,
 	}, cleanup, nil
 }
+
+// Redis returns the Redis client, which is a single node, sentinel or
+// cluster client depending on conf.Data.Redis.Mode.
+func (d *Data) Redis() redis.UniversalClient {
+	return d.rdb
+}
+
+// NewDBConfig converts the database config to an orm.DBConfig.
+func NewDBConfig(c *conf.Data_Database) *orm.DBConfig {
+	replicas := make([]orm.ReplicaConfig, 0, len(c.Replicas))
+	for _, r := range c.Replicas {
+		replicas = append(replicas, orm.ReplicaConfig{
+			Host:     r.Host,
+			Port:     fmt.Sprintf("%d", r.Port),
+			Username: r.Username,
+			Password: r.Password,
+		})
+	}
+
+	return &orm.DBConfig{
+		Driver:          c.Driver,
+		Username:        c.Username,
+		Password:        c.Password,
+		Host:            c.Host,
+		Port:            fmt.Sprintf("%d", c.Port),
+		DBName:          c.DbName,
+		MaxIdleConns:    int(c.MaxIdleConns),
+		MaxOpenConns:    int(c.MaxOpenConns),
+		DBCharset:       c.DbCharset,
+		ConnMaxLifetime: c.ConnMaxLifetime.AsDuration(),
+		ConnMaxIdleTime: c.ConnMaxIdleTime.AsDuration(),
+		SSLMode:         c.SslMode,
+		Replicas:        replicas,
+
+		ReplicaHealthCheckInterval: c.ReplicaHealthCheckInterval.AsDuration(),
+	}
+}
@@ -0,0 +1,52 @@
+package data
+
+import (
+	"errors"
+	"strconv"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"gorm.io/gorm"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+// dbError maps an error of a query on resource to a biz error:
+// gorm.ErrRecordNotFound becomes notFound, a duplicate key
+// biz.ErrAlreadyExists and a deadlock biz.ErrAborted, carrying the resource
+// and id as metadata and err as the cause. A notFound of nil, an empty
+// resource or a zero id are left out. Other errors are returned unchanged.
+func dbError(err error, notFound *kerrors.Error, resource string, id int64) error {
+	var se *kerrors.Error
+	if err == nil || errors.As(err, &se) {
+		return err
+	}
+
+	var target *kerrors.Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) && notFound != nil:
+		target = notFound
+	case orm.IsDuplicateKey(err):
+		target = biz.ErrAlreadyExists
+	case orm.IsDeadlock(err):
+		target = biz.ErrAborted
+	default:
+		return err
+	}
+	return withResource(target, resource, id).WithCause(err)
+}
+
+// withResource returns a copy of e with the resource and id metadata.
+func withResource(e *kerrors.Error, resource string, id int64) *kerrors.Error {
+	md := make(map[string]string, len(e.Metadata)+2)
+	for k, v := range e.Metadata {
+		md[k] = v
+	}
+	if resource != "" {
+		md[biz.MetadataResource] = resource
+	}
+	if id != 0 {
+		md[biz.MetadataID] = strconv.FormatInt(id, 10)
+	}
+	return e.WithMetadata(md)
+}
@@ -0,0 +1,67 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+)
+
+func TestDBError(t *testing.T) {
+	deadlock := fmt.Errorf("commit failed: %w", &mysql.MySQLError{Number: 1213})
+	tests := []struct {
+		name     string
+		err      error
+		notFound *kerrors.Error
+		id       int64
+		want     error
+		wantMD   map[string]string
+	}{
+		{name: "nil", err: nil, want: nil},
+		{
+			name:     "not found",
+			err:      gorm.ErrRecordNotFound,
+			notFound: biz.ErrGreeterNotFound,
+			id:       7,
+			want:     biz.ErrGreeterNotFound,
+			wantMD:   map[string]string{biz.MetadataResource: "greeter", biz.MetadataID: "7"},
+		},
+		{name: "not found without notFound", err: gorm.ErrRecordNotFound, want: gorm.ErrRecordNotFound},
+		{
+			name:   "duplicate key",
+			err:    gorm.ErrDuplicatedKey,
+			want:   biz.ErrAlreadyExists,
+			wantMD: map[string]string{biz.MetadataResource: "greeter"},
+		},
+		{
+			name:   "deadlock",
+			err:    deadlock,
+			id:     3,
+			want:   biz.ErrAborted,
+			wantMD: map[string]string{biz.MetadataResource: "greeter", biz.MetadataID: "3"},
+		},
+		{name: "biz error", err: biz.ErrHelloRequired, want: biz.ErrHelloRequired},
+		{name: "other", err: errors.New("boom"), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dbError(tt.err, tt.notFound, resourceGreeter, tt.id)
+			if tt.want == nil {
+				require.Equal(t, tt.err, got)
+				return
+			}
+			require.ErrorIs(t, got, tt.want)
+			if tt.wantMD != nil {
+				require.Equal(t, tt.wantMD, kerrors.FromError(got).Metadata)
+				// The driver error stays in the chain.
+				require.ErrorIs(t, got, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/pkg/event"
+	"github.com/go-kratos/kratos-layout/pkg/event/redisstream"
+)
+
+// defaultConsumerGroup is the consumer group of the service's instances.
+const defaultConsumerGroup = "kratos_layout"
+
+// NewBroker creates the event.Publisher the outbox relay publishes to, on
+// Redis Streams.
+func NewBroker(c *conf.Data, data *Data) event.Publisher {
+	return redisstream.NewPublisher(data.rdb, redisstream.WithMaxLen(c.GetOutbox().GetStreamMaxLen()))
+}
+
+// NewSubscriber creates the event.Subscriber of the event server, on Redis
+// Streams.
+func NewSubscriber(c *conf.Server, data *Data, logger log.Logger) event.Subscriber {
+	e := c.GetEvent()
+	group := e.GetGroup()
+	if group == "" {
+		group = defaultConsumerGroup
+	}
+	consumer := e.GetConsumer()
+	if consumer == "" {
+		consumer = defaultConsumer()
+	}
+	var opts []redisstream.Option
+	if d := e.GetRetryDelay().AsDuration(); d > 0 {
+		opts = append(opts, redisstream.WithRetryDelay(d))
+	}
+	if e.GetBatchSize() > 0 {
+		opts = append(opts, redisstream.WithBatchSize(int64(e.BatchSize)))
+	}
+	log.NewHelper(log.With(logger, "module", "data/event")).
+		Infof("consuming events in group %s as %s", group, consumer)
+	return redisstream.NewSubscriber(data.rdb, group, consumer, opts...)
+}
+
+// defaultConsumer returns the hostname and pid of the process. Instances
+// sharing a consumer name would read each other's pending messages, and
+// several instances may run on one host or under one container hostname.
+// The messages left pending by a stopped instance are claimed by the others
+// after the retry delay. The subscriber deletes the consumer when the event
+// server stops, but a crashed instance leaves it in the group; set a stable
+// consumer name, e.g. the pod name of a StatefulSet, where that matters.
+func defaultConsumer() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return host + "-" + strconv.Itoa(os.Getpid())
+}
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/pkg/event"
+)
+
+func TestDefaultConsumer(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	consumer := defaultConsumer()
+	require.True(t, strings.HasPrefix(consumer, host+"-"), consumer)
+	require.Equal(t, host+"-"+strconv.Itoa(os.Getpid()), consumer)
+}
+
+func TestNewSubscriber_ZeroRetryDelay(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	ctx := context.Background()
+
+	data := &Data{rdb: rdb}
+	newSubscriber := func(consumer string) event.Subscriber {
+		c := &conf.Server{Event: &conf.Server_Event{Consumer: consumer, RetryDelay: durationpb.New(0)}}
+		return NewSubscriber(c, data, log.DefaultLogger)
+	}
+	c1, c2 := newSubscriber("c1"), newSubscriber("c2")
+	require.NoError(t, NewBroker(&conf.Data{}, data).Publish(ctx, &event.Message{ID: "1", Topic: "t"}))
+
+	ds, err := c1.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+
+	// A zero retry delay keeps the default, so the message c1 is handling
+	// is not claimed by c2.
+	mr.FastForward(time.Second)
+	rctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	ds, err = c2.Receive(rctx, "t")
+	require.NoError(t, err)
+	require.Empty(t, ds)
+}
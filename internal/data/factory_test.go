@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+)
+
+// Factory builds entities with sensible defaults for tests and persists them.
+// Overrides are applied in order after the defaults.
+type Factory[T any] struct {
+	seq    atomic.Int64
+	build  func(seq int64) *T
+	create func(ctx context.Context, v *T) (*T, error)
+}
+
+// NewFactory creates a Factory. build returns the defaults of the seq-th
+// entity, which keeps unique fields unique; create persists an entity.
+func NewFactory[T any](build func(seq int64) *T, create func(ctx context.Context, v *T) (*T, error)) *Factory[T] {
+	return &Factory[T]{build: build, create: create}
+}
+
+// Build returns a new entity without persisting it.
+func (f *Factory[T]) Build(overrides ...func(*T)) *T {
+	v := f.build(f.seq.Add(1))
+	for _, o := range overrides {
+		o(v)
+	}
+	return v
+}
+
+// Create builds and persists an entity, failing t on error.
+func (f *Factory[T]) Create(t testing.TB, overrides ...func(*T)) *T {
+	t.Helper()
+	v, err := f.create(context.Background(), f.Build(overrides...))
+	if err != nil {
+		t.Fatalf("create %T failed: %v", v, err)
+	}
+	return v
+}
+
+// CreateN builds and persists n entities with the same overrides.
+func (f *Factory[T]) CreateN(t testing.TB, n int, overrides ...func(*T)) []*T {
+	t.Helper()
+	vs := make([]*T, 0, n)
+	for i := 0; i < n; i++ {
+		vs = append(vs, f.Create(t, overrides...))
+	}
+	return vs
+}
+
+// NewGreeterFactory creates a Factory of biz.Greeter persisted through repo.
+func NewGreeterFactory(repo biz.GreeterRepo) *Factory[biz.Greeter] {
+	return NewFactory(func(seq int64) *biz.Greeter {
+		return &biz.Greeter{Hello: fmt.Sprintf("greeter-%d", seq)}
+	}, repo.Save)
+}
+
+// WithHello overrides the Hello of a biz.Greeter.
+func WithHello(hello string) func(*biz.Greeter) {
+	return func(g *biz.Greeter) {
+		g.Hello = hello
+	}
+}
+
+func TestGreeterFactory(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	factory := NewGreeterFactory(repo)
+
+	built := factory.Build()
+	require.Zero(t, built.ID)
+	require.NotEqual(t, built.Hello, factory.Build().Hello)
+
+	g := factory.Create(t, WithHello("custom"))
+	require.NotZero(t, g.ID)
+	require.Equal(t, "custom", g.Hello)
+
+	gs := factory.CreateN(t, 3)
+	require.Len(t, gs, 3)
+
+	all, err := repo.ListAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+}
+
+func TestTestSuite_LoadFixtures(t *testing.T) {
+	ts := testSuite.ForTest(t)
+	require.NoError(t, ts.LoadFixtures("testdata/fixtures/greeters.yaml"))
+
+	repo := NewGreeterRepo(&Data{db: ts.DB(), rdb: ts.Redis()}, log.DefaultLogger)
+	gs, err := repo.ListByHello(context.Background(), "kratos")
+	require.NoError(t, err)
+	require.Len(t, gs, 2)
+
+	all, err := repo.ListAll(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"world", "kratos", "kratos"}, hellos(all))
+}
+
+func hellos(gs []*biz.Greeter) []string {
+	out := make([]string, 0, len(gs))
+	for _, g := range gs {
+		out = append(out, g.Hello)
+	}
+	return out
+}
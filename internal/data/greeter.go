@@ -3,40 +3,198 @@ package data
 import (
 	"context"
 
-	"github.com/go-kratos/kratos-layout/internal/biz"
-
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
 )
 
+// resourceGreeter is the resource name in the metadata of greeter errors.
+const resourceGreeter = "greeter"
+
+// Greeter is the persistent model of biz.Greeter. Deleting it is soft.
+type Greeter struct {
+	ID      int64  `gorm:"primaryKey;autoIncrement"`
+	Hello   string `gorm:"size:255;not null;index"`
+	Version int64  `gorm:"not null;default:1"`
+	orm.Model
+}
+
+// TableName returns the table name of Greeter.
+func (Greeter) TableName() string {
+	return "greeters"
+}
+
+func (g *Greeter) toBiz() *biz.Greeter {
+	bg := &biz.Greeter{
+		ID:        g.ID,
+		Hello:     g.Hello,
+		Version:   g.Version,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+		CreatedBy: g.CreatedBy,
+		UpdatedBy: g.UpdatedBy,
+	}
+	if g.DeletedAt.Valid {
+		bg.DeletedAt = g.DeletedAt.Time
+	}
+	return bg
+}
+
+func greeterFromBiz(g *biz.Greeter) *Greeter {
+	return &Greeter{
+		ID:      g.ID,
+		Hello:   g.Hello,
+		Version: g.Version,
+		Model: orm.Model{
+			CreatedAt: g.CreatedAt,
+			UpdatedAt: g.UpdatedAt,
+			CreatedBy: g.CreatedBy,
+			UpdatedBy: g.UpdatedBy,
+		},
+	}
+}
+
+// greeterColumns maps the list query fields of greeters to their columns.
+var greeterColumns = map[string]queryColumn[Greeter]{
+	"id":          {name: "id", value: func(g *Greeter) any { return g.ID }},
+	"hello":       {name: "hello", value: func(g *Greeter) any { return g.Hello }},
+	"create_time": {name: "created_at", value: func(g *Greeter) any { return g.CreatedAt }},
+	"update_time": {name: "updated_at", value: func(g *Greeter) any { return g.UpdatedAt }},
+}
+
 type greeterRepo struct {
 	data *Data
 	log  *log.Helper
 }
 
-// NewGreeterRepo creates a new GreeterRepo.
+// NewGreeterRepo creates a new GreeterRepo, cached in Redis when data has a client.
 func NewGreeterRepo(data *Data, logger log.Logger) biz.GreeterRepo {
-	return &greeterRepo{
+	repo := &greeterRepo{
 		data: data,
 		log:  log.NewHelper(logger),
 	}
+	if data.rdb == nil {
+		return repo
+	}
+	return newCachedGreeterRepo(repo, data, logger)
 }
 
 func (r *greeterRepo) Save(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
-	return g, nil
+	r.log.WithContext(ctx).Debugf("Save: %v", g.Hello)
+
+	po := greeterFromBiz(g)
+	po.Version = 1
+	if err := r.data.DB(ctx).Create(po).Error; err != nil {
+		return nil, dbError(err, nil, resourceGreeter, g.ID)
+	}
+	return po.toBiz(), nil
 }
 
 func (r *greeterRepo) Update(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
-	return g, nil
+	r.log.WithContext(ctx).Debugf("Update: %v", g.Hello)
+
+	db := r.data.DB(ctx).Model(&Greeter{ID: g.ID})
+	if g.Version != 0 {
+		db = db.Where("version = ?", g.Version)
+	}
+	result := db.Updates(map[string]any{
+		"hello":   g.Hello,
+		"version": gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return nil, dbError(result.Error, biz.ErrGreeterNotFound, resourceGreeter, g.ID)
+	}
+	if result.RowsAffected == 0 {
+		// Tell a missing greeter from a newer version.
+		if _, err := r.FindByID(orm.WithPrimary(ctx), g.ID); err != nil {
+			return nil, err
+		}
+		return nil, withResource(biz.ErrVersionMismatch, resourceGreeter, g.ID)
+	}
+	return r.FindByID(orm.WithPrimary(ctx), g.ID)
+}
+
+func (r *greeterRepo) FindByID(ctx context.Context, id int64) (*biz.Greeter, error) {
+	var po Greeter
+	if err := r.data.DB(ctx).First(&po, id).Error; err != nil {
+		return nil, dbError(err, biz.ErrGreeterNotFound, resourceGreeter, id)
+	}
+	return po.toBiz(), nil
+}
+
+func (r *greeterRepo) ListByHello(ctx context.Context, hello string) ([]*biz.Greeter, error) {
+	var pos []*Greeter
+	if err := r.data.DB(ctx).Where("hello = ?", hello).Order("id").Find(&pos).Error; err != nil {
+		return nil, dbError(err, nil, resourceGreeter, 0)
+	}
+	return greetersToBiz(pos), nil
+}
+
+func (r *greeterRepo) ListAll(ctx context.Context) ([]*biz.Greeter, error) {
+	var pos []*Greeter
+	if err := r.data.DB(ctx).Order("id").Find(&pos).Error; err != nil {
+		return nil, dbError(err, nil, resourceGreeter, 0)
+	}
+	return greetersToBiz(pos), nil
 }
 
-func (r *greeterRepo) FindByID(context.Context, int64) (*biz.Greeter, error) {
-	return nil, nil
+func (r *greeterRepo) List(ctx context.Context, q *biz.ListQuery) (*biz.Page[*biz.Greeter], error) {
+	db := r.data.DB(ctx).Model(&Greeter{})
+	if q.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	pos, next, err := listPage(db, q, greeterColumns)
+	if err != nil {
+		return nil, dbError(err, nil, resourceGreeter, 0)
+	}
+	return &biz.Page[*biz.Greeter]{Items: greetersToBiz(pos), Next: next}, nil
 }
 
-func (r *greeterRepo) ListByHello(context.Context, string) ([]*biz.Greeter, error) {
-	return nil, nil
+func (r *greeterRepo) Delete(ctx context.Context, id int64) error {
+	r.log.WithContext(ctx).Debugf("Delete: %d", id)
+
+	// An update rather than a soft Delete, which runs no update hooks, so
+	// that orm.Model sets updated_by to the principal deleting the greeter,
+	// and its etag changes.
+	db := r.data.DB(ctx)
+	result := db.Model(&Greeter{ID: id}).
+		Updates(map[string]any{
+			"deleted_at": db.NowFunc(),
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return dbError(result.Error, biz.ErrGreeterNotFound, resourceGreeter, id)
+	}
+	if result.RowsAffected == 0 {
+		return withResource(biz.ErrGreeterNotFound, resourceGreeter, id)
+	}
+	return nil
 }
 
-func (r *greeterRepo) ListAll(context.Context) ([]*biz.Greeter, error) {
-	return nil, nil
+// Restore undeletes a soft-deleted greeter. Restoring a greeter that is not
+// deleted returns it unchanged.
+func (r *greeterRepo) Restore(ctx context.Context, id int64) (*biz.Greeter, error) {
+	r.log.WithContext(ctx).Debugf("Restore: %d", id)
+
+	result := r.data.DB(ctx).Unscoped().
+		Model(&Greeter{ID: id}).
+		Where("deleted_at IS NOT NULL").
+		Updates(map[string]any{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, dbError(result.Error, biz.ErrGreeterNotFound, resourceGreeter, id)
+	}
+	return r.FindByID(orm.WithPrimary(ctx), id)
+}
+
+func greetersToBiz(pos []*Greeter) []*biz.Greeter {
+	gs := make([]*biz.Greeter, 0, len(pos))
+	for _, po := range pos {
+		gs = append(gs, po.toBiz())
+	}
+	return gs
 }
@@ -0,0 +1,157 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+// cachedGreeterRepo caches FindByID and ListByHello of a biz.GreeterRepo in
+// Redis and invalidates the affected entries on writes, once they commit.
+type cachedGreeterRepo struct {
+	biz.GreeterRepo
+
+	data    *Data
+	tx      biz.Transaction
+	byID    *Cache[*biz.Greeter]
+	byHello *Cache[[]*biz.Greeter]
+	log     *log.Helper
+}
+
+func newCachedGreeterRepo(repo biz.GreeterRepo, data *Data, logger log.Logger) biz.GreeterRepo {
+	return &cachedGreeterRepo{
+		GreeterRepo: repo,
+		data:        data,
+		tx:          NewTransaction(data),
+		byID: NewCache[*biz.Greeter](data.rdb, "greeter:id", logger,
+			WithNegativeCache(biz.ErrGreeterNotFound, 30*time.Second)),
+		byHello: NewCache[[]*biz.Greeter](data.rdb, "greeter:hello", logger),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// bypass reports whether reads must skip the cache: inside a transaction the
+// rows may be uncommitted, and a forced primary read wants its own writes.
+func bypass(ctx context.Context) bool {
+	_, inTx := ctx.Value(txKey{}).(*gorm.DB)
+	return inTx || orm.IsPrimary(ctx)
+}
+
+func (r *cachedGreeterRepo) Save(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	saved, err := r.GreeterRepo.Save(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	// The id may be negatively cached by an earlier lookup.
+	r.invalidate(ctx, saved.ID, saved.Hello)
+	return saved, nil
+}
+
+// Update and Delete lock the row while they write it, so that the hello they
+// invalidate is the one they replace.
+func (r *cachedGreeterRepo) Update(ctx context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	var updated *biz.Greeter
+	err := r.tx.InTx(ctx, func(ctx context.Context) error {
+		old, err := r.findForUpdate(ctx, g.ID)
+		if err != nil {
+			return err
+		}
+		if updated, err = r.GreeterRepo.Update(ctx, g); err != nil {
+			return err
+		}
+		r.invalidate(ctx, g.ID, old.Hello, updated.Hello)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *cachedGreeterRepo) Delete(ctx context.Context, id int64) error {
+	return r.tx.InTx(ctx, func(ctx context.Context) error {
+		old, err := r.findForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.GreeterRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		r.invalidate(ctx, id, old.Hello)
+		return nil
+	})
+}
+
+func (r *cachedGreeterRepo) Restore(ctx context.Context, id int64) (*biz.Greeter, error) {
+	restored, err := r.GreeterRepo.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// The id is negatively cached since the greeter was deleted.
+	r.invalidate(ctx, id, restored.Hello)
+	return restored, nil
+}
+
+// findForUpdate returns the greeter with id, locking its row until the
+// transaction of ctx ends.
+func (r *cachedGreeterRepo) findForUpdate(ctx context.Context, id int64) (*biz.Greeter, error) {
+	var po Greeter
+	err := r.data.DB(ctx).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).First(&po, id).Error
+	if err != nil {
+		return nil, dbError(err, biz.ErrGreeterNotFound, resourceGreeter, id)
+	}
+	return po.toBiz(), nil
+}
+
+func (r *cachedGreeterRepo) FindByID(ctx context.Context, id int64) (*biz.Greeter, error) {
+	if bypass(ctx) {
+		return r.GreeterRepo.FindByID(ctx, id)
+	}
+	// Loads read the primary, as a lagging replica could return the values
+	// a committed write just invalidated.
+	g, err := r.byID.Get(ctx, strconv.FormatInt(id, 10), func(ctx context.Context) (*biz.Greeter, error) {
+		return r.GreeterRepo.FindByID(orm.WithPrimary(ctx), id)
+	})
+	if errors.Is(err, biz.ErrGreeterNotFound) {
+		// A negative cache hit returns the bare biz error.
+		return nil, withResource(biz.ErrGreeterNotFound, resourceGreeter, id)
+	}
+	return g, err
+}
+
+func (r *cachedGreeterRepo) ListByHello(ctx context.Context, hello string) ([]*biz.Greeter, error) {
+	if bypass(ctx) {
+		return r.GreeterRepo.ListByHello(ctx, hello)
+	}
+	return r.byHello.Get(ctx, hello, func(ctx context.Context) ([]*biz.Greeter, error) {
+		return r.GreeterRepo.ListByHello(orm.WithPrimary(ctx), hello)
+	})
+}
+
+// invalidate deletes the cached entries of a greeter once the transaction of
+// ctx commits, so that it also removes the values it replaces that reads
+// cached meanwhile. Reads still loading them lose their lease on the entries
+// and cache nothing, see Cache. Failures are logged, since the write itself
+// succeeded and entries expire with their TTL.
+func (r *cachedGreeterRepo) invalidate(ctx context.Context, id int64, hellos ...string) {
+	r.data.AfterCommit(ctx, func(ctx context.Context) {
+		r.deleteEntries(ctx, id, hellos...)
+	})
+}
+
+func (r *cachedGreeterRepo) deleteEntries(ctx context.Context, id int64, hellos ...string) {
+	if err := r.byID.Delete(ctx, strconv.FormatInt(id, 10)); err != nil {
+		r.log.WithContext(ctx).Errorf("invalidate greeter %d failed: %v", id, err)
+	}
+	if err := r.byHello.Delete(ctx, hellos...); err != nil {
+		r.log.WithContext(ctx).Errorf("invalidate greeters by hello failed: %v", err)
+	}
+}
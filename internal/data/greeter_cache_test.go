@@ -0,0 +1,230 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+// hookedGreeterRepo records whether FindByID and ListByHello read the primary,
+// and calls afterFind once FindByID read the greeter.
+type hookedGreeterRepo struct {
+	biz.GreeterRepo
+
+	primary   []bool
+	afterFind func()
+}
+
+func (r *hookedGreeterRepo) FindByID(ctx context.Context, id int64) (*biz.Greeter, error) {
+	r.primary = append(r.primary, orm.IsPrimary(ctx))
+	g, err := r.GreeterRepo.FindByID(ctx, id)
+	if r.afterFind != nil {
+		r.afterFind()
+	}
+	return g, err
+}
+
+func (r *hookedGreeterRepo) ListByHello(ctx context.Context, hello string) ([]*biz.Greeter, error) {
+	r.primary = append(r.primary, orm.IsPrimary(ctx))
+	return r.GreeterRepo.ListByHello(ctx, hello)
+}
+
+func newHookedGreeterRepo(t *testing.T) (*hookedGreeterRepo, biz.GreeterRepo) {
+	t.Helper()
+	require.NoError(t, testSuite.ClearAll())
+	t.Cleanup(func() {
+		require.NoError(t, testSuite.ClearAll())
+	})
+	data := &Data{db: testSuite.DB(), rdb: testSuite.Redis()}
+	hooked := &hookedGreeterRepo{GreeterRepo: &greeterRepo{data: data, log: log.NewHelper(log.DefaultLogger)}}
+	return hooked, newCachedGreeterRepo(hooked, data, log.DefaultLogger)
+}
+
+// updateHelloDirectly changes a greeter behind the cache's back.
+func updateHelloDirectly(t *testing.T, id int64, hello string) {
+	t.Helper()
+	require.NoError(t, testSuite.DB().Model(&Greeter{ID: id}).Update("hello", hello).Error)
+}
+
+func TestCachedGreeterRepo_FindByID(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	g, err := repo.Save(ctx, &biz.Greeter{Hello: "cached"})
+	require.NoError(t, err)
+	_, err = repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+
+	updateHelloDirectly(t, g.ID, "stale")
+	got, err := repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	require.Equal(t, "cached", got.Hello)
+
+	// Primary reads skip the cache.
+	got, err = repo.FindByID(orm.WithPrimary(ctx), g.ID)
+	require.NoError(t, err)
+	require.Equal(t, "stale", got.Hello)
+}
+
+func TestCachedGreeterRepo_LoadFromPrimary(t *testing.T) {
+	hooked, repo := newHookedGreeterRepo(t)
+	ctx := context.Background()
+
+	g, err := repo.Save(ctx, &biz.Greeter{Hello: "a"})
+	require.NoError(t, err)
+	_, err = repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	_, err = repo.ListByHello(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, true}, hooked.primary)
+}
+
+func TestCachedGreeterRepo_UpdateDuringLoad(t *testing.T) {
+	hooked, repo := newHookedGreeterRepo(t)
+	ctx := context.Background()
+
+	g, err := repo.Save(ctx, &biz.Greeter{Hello: "old"})
+	require.NoError(t, err)
+	// An update commits after a cache miss read the greeter, but before the
+	// miss caches it.
+	hooked.afterFind = func() {
+		hooked.afterFind = nil
+		_, err := repo.Update(ctx, &biz.Greeter{ID: g.ID, Hello: "new"})
+		require.NoError(t, err)
+	}
+	got, err := repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	require.Equal(t, "old", got.Hello)
+
+	got, err = repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	require.Equal(t, "new", got.Hello)
+}
+
+func TestCachedGreeterRepo_NegativeCache(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, 1)
+	require.ErrorIs(t, err, biz.ErrGreeterNotFound)
+	n, err := testSuite.Redis().Exists(ctx, "greeter:id:1").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// Saving the id invalidates its negative entry.
+	g, err := repo.Save(ctx, &biz.Greeter{Hello: "new"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), g.ID)
+	got, err := repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	require.Equal(t, "new", got.Hello)
+}
+
+func TestCachedGreeterRepo_InvalidateOnWrite(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	g, err := repo.Save(ctx, &biz.Greeter{Hello: "a"})
+	require.NoError(t, err)
+	// Fill the id and both hello entries.
+	_, err = repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	byA, err := repo.ListByHello(ctx, "a")
+	require.NoError(t, err)
+	require.Len(t, byA, 1)
+	byB, err := repo.ListByHello(ctx, "b")
+	require.NoError(t, err)
+	require.Empty(t, byB)
+
+	_, err = repo.Update(ctx, &biz.Greeter{ID: g.ID, Hello: "b"})
+	require.NoError(t, err)
+
+	got, err := repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	require.Equal(t, "b", got.Hello)
+	byA, err = repo.ListByHello(ctx, "a")
+	require.NoError(t, err)
+	require.Empty(t, byA)
+	byB, err = repo.ListByHello(ctx, "b")
+	require.NoError(t, err)
+	require.Len(t, byB, 1)
+
+	// Saving another greeter invalidates the list it belongs to.
+	_, err = repo.Save(ctx, &biz.Greeter{Hello: "b"})
+	require.NoError(t, err)
+	byB, err = repo.ListByHello(ctx, "b")
+	require.NoError(t, err)
+	require.Len(t, byB, 2)
+
+	// Restoring a deleted greeter drops its cached not-found.
+	require.NoError(t, repo.Delete(ctx, g.ID))
+	_, err = repo.FindByID(ctx, g.ID)
+	require.ErrorIs(t, err, biz.ErrGreeterNotFound)
+	_, err = repo.Restore(ctx, g.ID)
+	require.NoError(t, err)
+	got, err = repo.FindByID(ctx, g.ID)
+	require.NoError(t, err)
+	require.Equal(t, "b", got.Hello)
+}
+
+func TestCachedGreeterRepo_BypassInTransaction(t *testing.T) {
+	tx, repo := newTestTransaction(t)
+	ctx := context.Background()
+
+	err := tx.InTx(ctx, func(ctx context.Context) error {
+		g, err := repo.Save(ctx, &biz.Greeter{Hello: "uncommitted"})
+		require.NoError(t, err)
+		_, err = repo.FindByID(ctx, g.ID)
+		require.NoError(t, err)
+		_, err = repo.ListByHello(ctx, "uncommitted")
+		require.NoError(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+
+	keys, err := testSuite.Redis().Keys(ctx, "greeter:*").Result()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestCachedGreeterRepo_InvalidateAfterCommit(t *testing.T) {
+	tx, repo := newTestTransaction(t)
+	ctx := context.Background()
+	cached := func() int64 {
+		n, err := testSuite.Redis().Exists(ctx, "greeter:hello:new").Result()
+		require.NoError(t, err)
+		return n
+	}
+
+	gs, err := repo.ListByHello(ctx, "new")
+	require.NoError(t, err)
+	require.Empty(t, gs)
+	require.Equal(t, int64(1), cached())
+
+	// A rolled back save leaves the cache as it is.
+	errBoom := errors.New("boom")
+	require.ErrorIs(t, tx.InTx(ctx, func(ctx context.Context) error {
+		_, err := repo.Save(ctx, &biz.Greeter{Hello: "new"})
+		require.NoError(t, err)
+		return errBoom
+	}), errBoom)
+	require.Equal(t, int64(1), cached())
+
+	// A committed save invalidates the cache once it commits.
+	require.NoError(t, tx.InTx(ctx, func(ctx context.Context) error {
+		_, err := repo.Save(ctx, &biz.Greeter{Hello: "new"})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), cached())
+		return nil
+	}))
+	require.Zero(t, cached())
+	gs, err = repo.ListByHello(ctx, "new")
+	require.NoError(t, err)
+	require.Len(t, gs, 1)
+}
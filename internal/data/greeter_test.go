@@ -0,0 +1,202 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/principal"
+)
+
+func newTestGreeterRepo(t *testing.T) biz.GreeterRepo {
+	t.Helper()
+	require.NoError(t, testSuite.ClearAll())
+	t.Cleanup(func() {
+		require.NoError(t, testSuite.ClearAll())
+	})
+	return NewGreeterRepo(&Data{db: testSuite.DB(), rdb: testSuite.Redis()}, log.DefaultLogger)
+}
+
+func TestGreeterRepo_SaveAndFindByID(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	saved, err := repo.Save(ctx, &biz.Greeter{Hello: "kratos"})
+	require.NoError(t, err)
+	require.NotZero(t, saved.ID)
+	require.Equal(t, "kratos", saved.Hello)
+	require.False(t, saved.CreatedAt.IsZero())
+
+	found, err := repo.FindByID(ctx, saved.ID)
+	require.NoError(t, err)
+	require.Equal(t, saved.ID, found.ID)
+	require.Equal(t, "kratos", found.Hello)
+}
+
+func TestGreeterRepo_FindByID_NotFound(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+
+	_, err := repo.FindByID(context.Background(), 404)
+	require.ErrorIs(t, err, biz.ErrGreeterNotFound)
+	require.True(t, v1.IsGreeterNotFound(err))
+	require.Equal(t, map[string]string{biz.MetadataResource: "greeter", biz.MetadataID: "404"},
+		kerrors.FromError(err).Metadata)
+}
+
+func TestGreeterRepo_Update(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	saved, err := repo.Save(ctx, &biz.Greeter{Hello: "before"})
+	require.NoError(t, err)
+
+	updated, err := repo.Update(ctx, &biz.Greeter{ID: saved.ID, Hello: "after"})
+	require.NoError(t, err)
+	require.Equal(t, saved.ID, updated.ID)
+	require.Equal(t, "after", updated.Hello)
+
+	_, err = repo.Update(ctx, &biz.Greeter{ID: saved.ID + 1000, Hello: "missing"})
+	require.ErrorIs(t, err, biz.ErrGreeterNotFound)
+}
+
+func TestGreeterRepo_Update_Version(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	saved, err := repo.Save(ctx, &biz.Greeter{Hello: "v1"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), saved.Version)
+
+	updated, err := repo.Update(ctx, &biz.Greeter{ID: saved.ID, Hello: "v2", Version: saved.Version})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.Version)
+
+	// An update based on the old version is a lost update.
+	_, err = repo.Update(ctx, &biz.Greeter{ID: saved.ID, Hello: "stale", Version: saved.Version})
+	require.ErrorIs(t, err, biz.ErrVersionMismatch)
+	require.True(t, v1.IsVersionMismatch(err))
+	found, err := repo.FindByID(ctx, saved.ID)
+	require.NoError(t, err)
+	require.Equal(t, "v2", found.Hello)
+
+	// Without a version the update is unconditional, and still bumps it.
+	updated, err = repo.Update(ctx, &biz.Greeter{ID: saved.ID, Hello: "v3"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), updated.Version)
+
+	_, err = repo.Update(ctx, &biz.Greeter{ID: saved.ID + 1000, Hello: "missing", Version: 1})
+	require.ErrorIs(t, err, biz.ErrGreeterNotFound)
+}
+
+func TestGreeterRepo_Delete(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	saved, err := repo.Save(ctx, &biz.Greeter{Hello: "doomed"})
+	require.NoError(t, err)
+	byHello, err := repo.ListByHello(ctx, "doomed")
+	require.NoError(t, err)
+	require.Len(t, byHello, 1)
+
+	require.NoError(t, repo.Delete(ctx, saved.ID))
+	_, err = repo.FindByID(ctx, saved.ID)
+	require.ErrorIs(t, err, biz.ErrGreeterNotFound)
+	byHello, err = repo.ListByHello(ctx, "doomed")
+	require.NoError(t, err)
+	require.Empty(t, byHello)
+
+	require.ErrorIs(t, repo.Delete(ctx, saved.ID), biz.ErrGreeterNotFound)
+}
+
+func TestGreeterRepo_List(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	for _, hello := range []string{"a", "b", "a"} {
+		_, err := repo.Save(ctx, &biz.Greeter{Hello: hello})
+		require.NoError(t, err)
+	}
+
+	all, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	byHello, err := repo.ListByHello(ctx, "a")
+	require.NoError(t, err)
+	require.Len(t, byHello, 2)
+	for _, g := range byHello {
+		require.Equal(t, "a", g.Hello)
+	}
+
+	none, err := repo.ListByHello(ctx, "missing")
+	require.NoError(t, err)
+	require.Empty(t, none)
+}
+
+func TestGreeterRepo_SoftDeleteAndRestore(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+
+	kept, err := repo.Save(ctx, &biz.Greeter{Hello: "kept"})
+	require.NoError(t, err)
+	saved, err := repo.Save(ctx, &biz.Greeter{Hello: "doomed"})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, saved.ID))
+
+	page, err := repo.List(ctx, &biz.ListQuery{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, kept.ID, page.Items[0].ID)
+
+	page, err = repo.List(ctx, &biz.ListQuery{PageSize: 10, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, saved.ID, page.Items[1].ID)
+	require.False(t, page.Items[1].DeletedAt.IsZero())
+
+	restored, err := repo.Restore(ctx, saved.ID)
+	require.NoError(t, err)
+	require.Equal(t, "doomed", restored.Hello)
+	require.True(t, restored.DeletedAt.IsZero())
+	// Deleting and restoring both change the etag.
+	require.Equal(t, saved.Version+2, restored.Version)
+	_, err = repo.FindByID(ctx, saved.ID)
+	require.NoError(t, err)
+
+	// Restoring a greeter that is not deleted leaves it alone.
+	again, err := repo.Restore(ctx, saved.ID)
+	require.NoError(t, err)
+	require.Equal(t, restored.Version, again.Version)
+
+	_, err = repo.Restore(ctx, saved.ID+1000)
+	require.ErrorIs(t, err, biz.ErrGreeterNotFound)
+}
+
+func TestGreeterRepo_Audit(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+
+	saved, err := repo.Save(principal.NewContext(context.Background(), "alice"), &biz.Greeter{Hello: "a"})
+	require.NoError(t, err)
+	require.Equal(t, "alice", saved.CreatedBy)
+	require.Equal(t, "alice", saved.UpdatedBy)
+
+	updated, err := repo.Update(principal.NewContext(context.Background(), "bob"), &biz.Greeter{ID: saved.ID, Hello: "b"})
+	require.NoError(t, err)
+	require.Equal(t, "alice", updated.CreatedBy)
+	require.Equal(t, "bob", updated.UpdatedBy)
+
+	require.NoError(t, repo.Delete(principal.NewContext(context.Background(), "carol"), saved.ID))
+	page, err := repo.List(context.Background(), &biz.ListQuery{PageSize: 10, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	deleted := page.Items[0]
+	require.False(t, deleted.DeletedAt.IsZero())
+	require.Equal(t, "alice", deleted.CreatedBy)
+	require.Equal(t, "carol", deleted.UpdatedBy)
+	require.Equal(t, updated.Version+1, deleted.Version)
+}
@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/go-kratos/kratos-layout/pkg/middleware/idempotency"
+)
+
+// reserveScript stores ARGV[1] unless the key exists, and returns the
+// existing value otherwise.
+var reserveScript = redis.NewScript(`
+local existing = redis.call('GET', KEYS[1])
+if existing then
+  return existing
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return false
+`)
+
+// completeScript replaces KEYS[1] with ARGV[2] for ARGV[3] milliseconds if
+// it still holds the reservation ARGV[1].
+var completeScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+  return 1
+end
+return 0
+`)
+
+type idempotencyStore struct {
+	rdb redis.UniversalClient
+}
+
+// NewIdempotencyStore creates an idempotency.Store on Redis, or returns nil
+// when data has no Redis client.
+func NewIdempotencyStore(data *Data) idempotency.Store {
+	if data.rdb == nil {
+		return nil
+	}
+	return &idempotencyStore{rdb: data.rdb}
+}
+
+func (s *idempotencyStore) Reserve(ctx context.Context, key string, rec *idempotency.Record, ttl time.Duration) (*idempotency.Record, error) {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := reserveScript.Run(ctx, s.rdb, []string{idempotencyKey(key)}, b, ttl.Milliseconds()).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reserve idempotency key %s failed: %w", key, err)
+	}
+	var stored idempotency.Record
+	if err := json.Unmarshal([]byte(existing), &stored); err != nil {
+		return nil, fmt.Errorf("decode idempotency record %s failed: %w", key, err)
+	}
+	return &stored, nil
+}
+
+// Save and Delete match the reservation by its encoding, which Reserve
+// stored, so that they compare the fingerprint and owner of the request.
+func (s *idempotencyStore) Save(ctx context.Context, key string, reserved, rec *idempotency.Record, ttl time.Duration) error {
+	r, err := json.Marshal(reserved)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	n, err := completeScript.Run(ctx, s.rdb, []string{idempotencyKey(key)}, r, b, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("save idempotency record %s failed: %w", key, err)
+	}
+	if n == 0 {
+		return idempotency.ErrNotReserved
+	}
+	return nil
+}
+
+func (s *idempotencyStore) Delete(ctx context.Context, key string, reserved *idempotency.Record) error {
+	r, err := json.Marshal(reserved)
+	if err != nil {
+		return err
+	}
+	// releaseScript of the locker deletes the key if it holds r.
+	n, err := releaseScript.Run(ctx, s.rdb, []string{idempotencyKey(key)}, r).Int64()
+	if err != nil {
+		return fmt.Errorf("delete idempotency record %s failed: %w", key, err)
+	}
+	if n == 0 {
+		return idempotency.ErrNotReserved
+	}
+	return nil
+}
+
+func idempotencyKey(key string) string {
+	return "idempotency:" + key
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/pkg/middleware/idempotency"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	store := NewIdempotencyStore(&Data{rdb: rdb})
+	ctx := context.Background()
+
+	pending := &idempotency.Record{Fingerprint: "f1", Owner: "o1"}
+	existing, err := store.Reserve(ctx, "op::k1", pending, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+	require.Equal(t, time.Minute, mr.TTL("idempotency:op::k1"))
+
+	existing, err = store.Reserve(ctx, "op::k1", &idempotency.Record{Fingerprint: "f2", Owner: "o2"}, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, pending, existing)
+
+	done := &idempotency.Record{Fingerprint: "f1", Owner: "o1", Done: true, Reply: []byte{1, 2, 3}}
+	require.NoError(t, store.Save(ctx, "op::k1", pending, done, time.Hour))
+	existing, err = store.Reserve(ctx, "op::k1", pending, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, done, existing)
+	require.Equal(t, time.Hour, mr.TTL("idempotency:op::k1"))
+
+	// A done record is no reservation anymore.
+	require.ErrorIs(t, store.Delete(ctx, "op::k1", pending), idempotency.ErrNotReserved)
+	require.ErrorIs(t, store.Save(ctx, "op::k1", pending, done, time.Hour), idempotency.ErrNotReserved)
+
+	existing, err = store.Reserve(ctx, "op::k2", pending, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+	require.NoError(t, store.Delete(ctx, "op::k2", pending))
+	existing, err = store.Reserve(ctx, "op::k2", pending, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	// In-progress markers expire, so a crashed request frees its key.
+	mr.FastForward(time.Minute)
+	existing, err = store.Reserve(ctx, "op::k2", pending, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+}
+
+func TestIdempotencyStore_ExpiredReservation(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	store := NewIdempotencyStore(&Data{rdb: rdb})
+	ctx := context.Background()
+
+	// The reservation of a slow request expires, and a retry with the same
+	// fingerprint reserves the key again.
+	slow := &idempotency.Record{Fingerprint: "f1", Owner: "slow"}
+	_, err := store.Reserve(ctx, "op::k1", slow, time.Minute)
+	require.NoError(t, err)
+	mr.FastForward(time.Minute)
+	retry := &idempotency.Record{Fingerprint: "f1", Owner: "retry"}
+	existing, err := store.Reserve(ctx, "op::k1", retry, time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	// The slow request neither completes nor releases the retry's reservation.
+	done := &idempotency.Record{Fingerprint: "f1", Owner: "slow", Done: true}
+	require.ErrorIs(t, store.Save(ctx, "op::k1", slow, done, time.Hour), idempotency.ErrNotReserved)
+	require.ErrorIs(t, store.Delete(ctx, "op::k1", slow), idempotency.ErrNotReserved)
+	existing, err = store.Reserve(ctx, "op::k1", slow, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, retry, existing)
+}
+
+func TestNewIdempotencyStore_WithoutRedis(t *testing.T) {
+	require.Nil(t, NewIdempotencyStore(&Data{}))
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+)
+
+// lockRetryInterval is how often Lock retries a held key.
+const lockRetryInterval = 50 * time.Millisecond
+
+var (
+	// acquireScript sets the lock and increments the fence counter atomically.
+	// It returns the new fence, or 0 when the lock is held.
+	acquireScript = redis.NewScript(`
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+  return redis.call('INCR', KEYS[2])
+end
+return 0
+`)
+	// releaseScript deletes the lock if it is still owned by ARGV[1].
+	releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+	// extendScript resets the TTL of the lock if it is still owned by ARGV[1].
+	extendScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+)
+
+type locker struct {
+	rdb           redis.UniversalClient
+	retryInterval time.Duration
+}
+
+// NewLocker creates a biz.Locker on Redis.
+func NewLocker(data *Data) biz.Locker {
+	return &locker{
+		rdb:           data.rdb,
+		retryInterval: lockRetryInterval,
+	}
+}
+
+func (l *locker) TryLock(ctx context.Context, key string, ttl time.Duration) (*biz.Lock, error) {
+	if err := checkLockTTL(key, ttl); err != nil {
+		return nil, err
+	}
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, err
+	}
+	lockKey, fenceKey := lockKeys(key)
+	fence, err := acquireScript.Run(ctx, l.rdb, []string{lockKey, fenceKey}, owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, fmt.Errorf("acquire lock %s failed: %w", key, err)
+	}
+	if fence == 0 {
+		return nil, biz.ErrLockNotAcquired
+	}
+	return &biz.Lock{Key: key, Owner: owner, Fence: fence}, nil
+}
+
+func (l *locker) Lock(ctx context.Context, key string, ttl time.Duration) (*biz.Lock, error) {
+	if err := checkLockTTL(key, ttl); err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(l.retryInterval)
+	defer ticker.Stop()
+	for {
+		lock, err := l.TryLock(ctx, key, ttl)
+		if !errors.Is(err, biz.ErrLockNotAcquired) {
+			return lock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("acquire lock %s failed: %w", key, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *locker) Unlock(ctx context.Context, lock *biz.Lock) error {
+	lockKey, _ := lockKeys(lock.Key)
+	n, err := releaseScript.Run(ctx, l.rdb, []string{lockKey}, lock.Owner).Int64()
+	if err != nil {
+		return fmt.Errorf("release lock %s failed: %w", lock.Key, err)
+	}
+	if n == 0 {
+		return biz.ErrLockNotHeld
+	}
+	return nil
+}
+
+func (l *locker) Extend(ctx context.Context, lock *biz.Lock, ttl time.Duration) error {
+	if err := checkLockTTL(lock.Key, ttl); err != nil {
+		return err
+	}
+	lockKey, _ := lockKeys(lock.Key)
+	n, err := extendScript.Run(ctx, l.rdb, []string{lockKey}, lock.Owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("extend lock %s failed: %w", lock.Key, err)
+	}
+	if n == 0 {
+		return biz.ErrLockNotHeld
+	}
+	return nil
+}
+
+// checkLockTTL rejects TTLs that round down to PX 0, which Redis refuses.
+func checkLockTTL(key string, ttl time.Duration) error {
+	if ttl < time.Millisecond {
+		return fmt.Errorf("lock %s ttl %v is less than 1ms", key, ttl)
+	}
+	return nil
+}
+
+// lockKeys returns the lock and fence counter keys of key. The hash tag
+// keeps both in one cluster slot, as the acquire script touches both.
+func lockKeys(key string) (lockKey, fenceKey string) {
+	return "lock:{" + key + "}", "lock:{" + key + "}:fence"
+}
+
+func newLockOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate lock owner failed: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
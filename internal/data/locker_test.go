@@ -0,0 +1,191 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+)
+
+func newTestLocker(t *testing.T) (biz.Locker, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	l := NewLocker(&Data{rdb: rdb}).(*locker)
+	l.retryInterval = time.Millisecond
+	return l, mr
+}
+
+func TestLocker_TryLock(t *testing.T) {
+	l, _ := newTestLocker(t)
+	ctx := context.Background()
+
+	lock, err := l.TryLock(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "job", lock.Key)
+	require.Equal(t, int64(1), lock.Fence)
+
+	_, err = l.TryLock(ctx, "job", time.Minute)
+	require.ErrorIs(t, err, biz.ErrLockNotAcquired)
+
+	// Other keys are independent.
+	other, err := l.TryLock(ctx, "other", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), other.Fence)
+
+	require.NoError(t, l.Unlock(ctx, lock))
+	require.ErrorIs(t, l.Unlock(ctx, lock), biz.ErrLockNotHeld)
+
+	relock, err := l.TryLock(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), relock.Fence)
+}
+
+func TestLocker_Expiry(t *testing.T) {
+	l, mr := newTestLocker(t)
+	ctx := context.Background()
+
+	stale, err := l.TryLock(ctx, "job", time.Second)
+	require.NoError(t, err)
+	mr.FastForward(2 * time.Second)
+
+	current, err := l.TryLock(ctx, "job", time.Minute)
+	require.NoError(t, err)
+	require.Greater(t, current.Fence, stale.Fence)
+
+	// The expired holder can neither release nor extend the new owner's lock.
+	require.ErrorIs(t, l.Unlock(ctx, stale), biz.ErrLockNotHeld)
+	require.ErrorIs(t, l.Extend(ctx, stale, time.Minute), biz.ErrLockNotHeld)
+	_, err = l.TryLock(ctx, "job", time.Minute)
+	require.ErrorIs(t, err, biz.ErrLockNotAcquired)
+
+	require.NoError(t, l.Unlock(ctx, current))
+}
+
+func TestLocker_Extend(t *testing.T) {
+	l, mr := newTestLocker(t)
+	ctx := context.Background()
+
+	lock, err := l.TryLock(ctx, "job", time.Second)
+	require.NoError(t, err)
+	require.NoError(t, l.Extend(ctx, lock, time.Minute))
+	require.Equal(t, time.Minute, mr.TTL("lock:{job}"))
+
+	mr.FastForward(2 * time.Second)
+	_, err = l.TryLock(ctx, "job", time.Second)
+	require.ErrorIs(t, err, biz.ErrLockNotAcquired)
+}
+
+func TestLocker_Lock(t *testing.T) {
+	l, _ := newTestLocker(t)
+	ctx := context.Background()
+
+	held, err := l.TryLock(ctx, "job", time.Minute)
+	require.NoError(t, err)
+
+	type result struct {
+		lock *biz.Lock
+		err  error
+	}
+	acquired := make(chan result)
+	go func() {
+		lock, err := l.Lock(ctx, "job", time.Minute)
+		acquired <- result{lock, err}
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Lock acquired a held key")
+	case <-time.After(20 * time.Millisecond):
+	}
+	require.NoError(t, l.Unlock(ctx, held))
+
+	select {
+	case res := <-acquired:
+		require.NoError(t, res.err)
+		require.Equal(t, held.Fence+1, res.lock.Fence)
+	case <-time.After(time.Second):
+		t.Fatal("Lock did not acquire the released key")
+	}
+}
+
+func TestLocker_LockCanceled(t *testing.T) {
+	l, _ := newTestLocker(t)
+
+	_, err := l.TryLock(context.Background(), "job", time.Minute)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.Lock(ctx, "job", time.Minute)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLocker_Contention(t *testing.T) {
+	l, _ := newTestLocker(t)
+	ctx := context.Background()
+
+	var (
+		wg      sync.WaitGroup
+		holders atomic.Int32
+		counter int
+		fences  = make(chan int64, 20)
+		errs    = make(chan error, 40)
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lock, err := l.Lock(ctx, "counter", time.Minute)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if n := holders.Add(1); n != 1 {
+				errs <- fmt.Errorf("%d holders of the lock", n)
+			}
+			counter++
+			fences <- lock.Fence
+			holders.Add(-1)
+			if err := l.Unlock(ctx, lock); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(fences)
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 20, counter)
+	seen := make(map[int64]bool)
+	for fence := range fences {
+		require.False(t, seen[fence], "fence %d issued twice", fence)
+		seen[fence] = true
+	}
+}
+
+func TestLocker_InvalidTTL(t *testing.T) {
+	l, _ := newTestLocker(t)
+	ctx := context.Background()
+
+	_, err := l.TryLock(ctx, "job", time.Microsecond)
+	require.ErrorContains(t, err, "ttl 1µs is less than 1ms")
+	_, err = l.Lock(ctx, "job", 0)
+	require.ErrorContains(t, err, "ttl 0s is less than 1ms")
+
+	lock, err := l.TryLock(ctx, "job", time.Millisecond)
+	require.NoError(t, err)
+	require.ErrorContains(t, l.Extend(ctx, lock, -time.Second), "less than 1ms")
+}
@@ -0,0 +1,25 @@
+// Package migrations embeds the versioned SQL migrations of the data layer.
+//
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql and
+// are applied by orm.Migrator in version order. Every supported driver has
+// its own directory, and a change must be added to each of them.
+package migrations
+
+import (
+	"embed"
+
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+// FS holds the embedded migration files.
+//
+//go:embed mysql postgres sqlite
+var FS embed.FS
+
+// Dir returns the directory of the migration files of driver within FS.
+func Dir(driver string) string {
+	if driver == "" {
+		return orm.DriverMySQL
+	}
+	return driver
+}
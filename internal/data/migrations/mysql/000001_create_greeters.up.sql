@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `greeters` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `hello` VARCHAR(255) NOT NULL,
+  `created_at` DATETIME(3) NULL,
+  `updated_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_greeters_hello` (`hello`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+ALTER TABLE `greeters` ADD COLUMN `version` BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+ALTER TABLE `greeters` ADD COLUMN `deleted_at` DATETIME(3) NULL;
+ALTER TABLE `greeters` ADD COLUMN `created_by` VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE `greeters` ADD COLUMN `updated_by` VARCHAR(64) NOT NULL DEFAULT '';
+CREATE INDEX `idx_greeters_deleted_at` ON `greeters` (`deleted_at`);
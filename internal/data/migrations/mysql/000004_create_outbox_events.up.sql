@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `outbox_events` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `topic` VARCHAR(255) NOT NULL,
+  `aggregate_id` VARCHAR(255) NOT NULL,
+  `payload` BLOB NOT NULL,
+  `created_at` DATETIME(3) NULL,
+  `attempts` INT NOT NULL DEFAULT 0,
+  `next_attempt_at` DATETIME(3) NULL,
+  `last_error` VARCHAR(1024) NOT NULL DEFAULT '',
+  `published_at` DATETIME(3) NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_outbox_events_aggregate_id` (`aggregate_id`, `id`),
+  KEY `idx_outbox_events_published_at` (`published_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "topic" VARCHAR(255) NOT NULL,
+  "aggregate_id" VARCHAR(255) NOT NULL,
+  "payload" BYTEA NOT NULL,
+  "created_at" TIMESTAMPTZ NULL,
+  "attempts" INTEGER NOT NULL DEFAULT 0,
+  "next_attempt_at" TIMESTAMPTZ NULL,
+  "last_error" VARCHAR(1024) NOT NULL DEFAULT '',
+  "published_at" TIMESTAMPTZ NULL
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_aggregate_id" ON "outbox_events" ("aggregate_id", "id");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_published_at" ON "outbox_events" ("published_at");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_greeters_deleted_at";
+ALTER TABLE "greeters" DROP COLUMN "updated_by";
+ALTER TABLE "greeters" DROP COLUMN "created_by";
+ALTER TABLE "greeters" DROP COLUMN "deleted_at";
@@ -0,0 +1,365 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/pkg/event"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/requestid"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+const (
+	defaultOutboxInterval        = time.Second
+	defaultOutboxBatchSize       = 100
+	defaultOutboxRetryBackoff    = time.Second
+	defaultOutboxMaxRetryBackoff = 5 * time.Minute
+	defaultOutboxRetention       = 24 * time.Hour
+
+	// outboxLockKey is held by the relay relaying a batch, so that only one
+	// instance publishes at a time.
+	outboxLockKey        = "outbox:relay"
+	defaultOutboxLockTTL = 30 * time.Second
+	// maxLastErrorLength is the size of the last_error column.
+	maxLastErrorLength = 1024
+)
+
+// outboxEvent is an event recorded in the outbox.
+type outboxEvent struct {
+	ID          int64 `gorm:"primaryKey;autoIncrement"`
+	Topic       string
+	AggregateID string
+	Payload     []byte
+	// Headers correlate the event with the request that recorded it.
+	Headers   map[string]string `gorm:"serializer:json"`
+	CreatedAt time.Time
+	// Attempts counts the failed publishes, and NextAttemptAt is when the
+	// relay publishes the event next.
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	// PublishedAt is nil until the event is published.
+	PublishedAt *time.Time
+}
+
+func (e *outboxEvent) message() *event.Message {
+	return &event.Message{
+		ID:      strconv.FormatInt(e.ID, 10),
+		Topic:   e.Topic,
+		Key:     e.AggregateID,
+		Payload: e.Payload,
+		Headers: e.Headers,
+	}
+}
+
+// eventHeaders returns the request ID and trace context of ctx as message
+// headers, which the middleware of the consuming event server picks up.
+func eventHeaders(ctx context.Context) map[string]string {
+	headers := make(map[string]string)
+	if id := requestid.FromContext(ctx); id != "" {
+		headers[requestid.HeaderKey] = id
+	}
+	propagation.TraceContext{}.Inject(ctx, propagation.MapCarrier(headers))
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+type eventPublisher struct {
+	data *Data
+}
+
+// NewEventPublisher creates a biz.EventPublisher recording events in the
+// outbox table, from where OutboxRelay publishes them.
+func NewEventPublisher(data *Data) biz.EventPublisher {
+	return &eventPublisher{data: data}
+}
+
+func (p *eventPublisher) Publish(ctx context.Context, events ...*biz.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	now := time.Now()
+	headers := eventHeaders(ctx)
+	rows := make([]*outboxEvent, 0, len(events))
+	for _, e := range events {
+		if e == nil || e.Payload == nil {
+			return errors.New("event has no payload")
+		}
+		topic := string(e.Payload.ProtoReflect().Descriptor().FullName())
+		payload, err := proto.Marshal(e.Payload)
+		if err != nil {
+			return fmt.Errorf("marshal event %s failed: %w", topic, err)
+		}
+		rows = append(rows, &outboxEvent{
+			Topic:         topic,
+			AggregateID:   e.AggregateID,
+			Payload:       payload,
+			Headers:       headers,
+			NextAttemptAt: now,
+		})
+	}
+	if err := p.data.DB(ctx).Create(&rows).Error; err != nil {
+		return dbError(err, nil, "", 0)
+	}
+	return nil
+}
+
+// OutboxRelay publishes the events recorded by the biz.EventPublisher to a
+// broker. It implements transport.Server to run along the app's servers.
+//
+// Events are published at least once, and the events of one aggregate in
+// the order they were recorded: after a failed publish, the later events of
+// the aggregate wait until the failed one is retried and published. Failed
+// events are retried with exponential backoff until they are published.
+// Published events are deleted after the retention period.
+type OutboxRelay struct {
+	data   *Data
+	locker biz.Locker
+	pub    event.Publisher
+	log    *log.Helper
+
+	interval        time.Duration
+	batchSize       int
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+	retention       time.Duration
+	lockTTL         time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewOutboxRelay creates an OutboxRelay publishing to pub.
+func NewOutboxRelay(c *conf.Data, data *Data, locker biz.Locker, pub event.Publisher, logger log.Logger) *OutboxRelay {
+	r := &OutboxRelay{
+		data:   data,
+		locker: locker,
+		pub:    pub,
+		log:    log.NewHelper(log.With(logger, "module", "data/outbox")),
+
+		interval:        defaultOutboxInterval,
+		batchSize:       defaultOutboxBatchSize,
+		retryBackoff:    defaultOutboxRetryBackoff,
+		maxRetryBackoff: defaultOutboxMaxRetryBackoff,
+		retention:       defaultOutboxRetention,
+		lockTTL:         defaultOutboxLockTTL,
+
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	o := c.GetOutbox()
+	if d := o.GetInterval().AsDuration(); d > 0 {
+		r.interval = d
+	}
+	if o.GetBatchSize() > 0 {
+		r.batchSize = int(o.BatchSize)
+	}
+	if d := o.GetRetryBackoff().AsDuration(); d > 0 {
+		r.retryBackoff = d
+	}
+	if d := o.GetMaxRetryBackoff().AsDuration(); d > 0 {
+		r.maxRetryBackoff = d
+	}
+	if d := o.GetRetention().AsDuration(); d > 0 {
+		r.retention = d
+	}
+	return r
+}
+
+// Start relays events every interval until Stop is called.
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	defer close(r.done)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-r.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.poll(ctx); err != nil && ctx.Err() == nil {
+				r.log.WithContext(ctx).Errorf("relay outbox failed: %v", err)
+			}
+		}
+	}
+}
+
+// Stop stops the relay, waiting for the batch in progress.
+func (r *OutboxRelay) Stop(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// poll relays the due events and deletes expired ones, unless another
+// instance is relaying.
+func (r *OutboxRelay) poll(ctx context.Context) error {
+	lock, err := r.locker.TryLock(ctx, outboxLockKey, r.lockTTL)
+	if errors.Is(err, biz.ErrLockNotAcquired) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.locker.Unlock(context.WithoutCancel(ctx), lock); err != nil {
+			r.log.WithContext(ctx).Warnf("unlock outbox relay failed: %v", err)
+		}
+	}()
+
+	// extend keeps the lock for the rest of the batch, the next batch and the
+	// cleanup. Once it expired, another instance may be relaying, so stop.
+	extended := time.Now()
+	extend := func(ctx context.Context) error {
+		if err := r.locker.Extend(ctx, lock, r.lockTTL); err != nil {
+			return fmt.Errorf("extend outbox relay lock failed: %w", err)
+		}
+		extended = time.Now()
+		return nil
+	}
+	// Within a batch, the lock is extended before a publish once half of it
+	// elapsed, so it outlasts the publish and its update.
+	keep := func(ctx context.Context) error {
+		if time.Since(extended) < r.lockTTL/2 {
+			return nil
+		}
+		return extend(ctx)
+	}
+	for {
+		_, read, err := r.relay(ctx, keep)
+		if err != nil {
+			return err
+		}
+		if err := extend(ctx); err != nil {
+			return err
+		}
+		// A full batch may have more events behind it, also when some of
+		// them failed or were held back.
+		if read < r.batchSize {
+			break
+		}
+	}
+	if _, err := r.cleanup(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// relay publishes one batch of due events, and returns the number of events
+// it published and the number it read. keep, if not nil, is called before
+// every publish to keep the relay lock.
+func (r *OutboxRelay) relay(ctx context.Context, keep func(context.Context) error) (published, read int, err error) {
+	now := time.Now()
+	// An event waits while an earlier event of its aggregate waits for a retry.
+	blocked := r.data.DB(ctx).Table("outbox_events AS earlier").Select("1").Where(
+		"earlier.aggregate_id = outbox_events.aggregate_id AND earlier.id < outbox_events.id"+
+			" AND earlier.published_at IS NULL AND earlier.next_attempt_at > ?", now)
+	var rows []*outboxEvent
+	err = r.data.DB(orm.WithPrimary(ctx)).
+		Where("published_at IS NULL AND next_attempt_at <= ?", now).
+		Where("NOT EXISTS (?)", blocked).
+		Order("id").
+		Limit(r.batchSize).
+		Find(&rows).Error
+	if err != nil {
+		return 0, 0, dbError(err, nil, "", 0)
+	}
+
+	failed := make(map[string]bool)
+	for _, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return published, len(rows), err
+		}
+		if failed[row.AggregateID] {
+			continue
+		}
+		if keep != nil {
+			if err := keep(ctx); err != nil {
+				return published, len(rows), err
+			}
+		}
+		if err := r.publish(ctx, row); err != nil {
+			failed[row.AggregateID] = true
+			r.log.WithContext(ctx).Warnf("publish outbox event %d failed: %v", row.ID, err)
+			if err := r.retryLater(ctx, row, err); err != nil {
+				return published, len(rows), err
+			}
+			continue
+		}
+		err := r.data.DB(ctx).Model(row).Update("published_at", time.Now()).Error
+		if err != nil {
+			return published, len(rows), dbError(err, nil, "", 0)
+		}
+		published++
+	}
+	return published, len(rows), nil
+}
+
+// publish publishes row, giving up after a third of the lock TTL so that a
+// hanging broker does not outlast the relay lock.
+func (r *OutboxRelay) publish(ctx context.Context, row *outboxEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, r.lockTTL/3)
+	defer cancel()
+	return r.pub.Publish(ctx, row.message())
+}
+
+// retryLater records a failed publish of row and schedules its retry.
+func (r *OutboxRelay) retryLater(ctx context.Context, row *outboxEvent, cause error) error {
+	msg := cause.Error()
+	if len(msg) > maxLastErrorLength {
+		msg = msg[:maxLastErrorLength]
+	}
+	err := r.data.DB(ctx).Model(row).Updates(map[string]any{
+		"attempts":        row.Attempts + 1,
+		"next_attempt_at": time.Now().Add(r.backoff(row.Attempts + 1)),
+		"last_error":      msg,
+	}).Error
+	return dbError(err, nil, "", 0)
+}
+
+// backoff returns the delay before the next publish of an event that failed
+// attempts times.
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	d := r.retryBackoff
+	for i := 1; i < attempts && d < r.maxRetryBackoff; i++ {
+		d *= 2
+	}
+	return min(d, r.maxRetryBackoff)
+}
+
+// cleanup deletes the events published before the retention period.
+func (r *OutboxRelay) cleanup(ctx context.Context) (int64, error) {
+	result := r.data.DB(ctx).
+		Where("published_at < ?", time.Now().Add(-r.retention)).
+		Delete(&outboxEvent{})
+	if result.Error != nil {
+		return 0, dbError(result.Error, nil, "", 0)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,379 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/pkg/event"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/requestid"
+)
+
+const topicGreeterCreated = "helloworld.v1.GreeterCreated"
+
+// flakyPublisher fails the publishes of the keys in fail.
+type flakyPublisher struct {
+	event.Publisher
+
+	mu   sync.Mutex
+	fail map[string]bool
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, msg *event.Message) error {
+	p.mu.Lock()
+	fail := p.fail[msg.Key]
+	p.mu.Unlock()
+	if fail {
+		return errors.New("broker unavailable")
+	}
+	return p.Publisher.Publish(ctx, msg)
+}
+
+func (p *flakyPublisher) setFail(key string, fail bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fail[key] = fail
+}
+
+// hookPublisher calls before ahead of the nth publish, counting from 1.
+type hookPublisher struct {
+	event.Publisher
+
+	n      int
+	before func(ctx context.Context, n int)
+}
+
+func (p *hookPublisher) Publish(ctx context.Context, msg *event.Message) error {
+	p.n++
+	p.before(ctx, p.n)
+	return p.Publisher.Publish(ctx, msg)
+}
+
+func newTestOutbox(t *testing.T) (*Data, *OutboxRelay, *event.MemoryBroker, *flakyPublisher) {
+	t.Helper()
+	require.NoError(t, testSuite.ClearAll())
+	t.Cleanup(func() {
+		require.NoError(t, testSuite.ClearAll())
+	})
+	data := &Data{db: testSuite.DB(), rdb: testSuite.Redis()}
+	broker := event.NewMemoryBroker()
+	pub := &flakyPublisher{Publisher: broker, fail: make(map[string]bool)}
+	relay := NewOutboxRelay(&conf.Data{}, data, NewLocker(data), pub, log.DefaultLogger)
+	return data, relay, broker, pub
+}
+
+func publishGreeterCreated(t *testing.T, ctx context.Context, events biz.EventPublisher, ids ...int64) {
+	t.Helper()
+	for _, id := range ids {
+		require.NoError(t, events.Publish(ctx, &biz.Event{
+			AggregateID: aggregateOf(id),
+			Payload:     &v1.GreeterCreated{Id: id},
+		}))
+	}
+}
+
+// aggregateOf puts the events with ids ending in 1 in aggregate "a", and
+// those ending in 2 in aggregate "b".
+func aggregateOf(id int64) string {
+	return map[int64]string{1: "a", 2: "b"}[id%10]
+}
+
+func publishedIDs(t *testing.T, broker *event.MemoryBroker) []int64 {
+	t.Helper()
+	var ids []int64
+	for _, msg := range broker.Messages(topicGreeterCreated) {
+		var e v1.GreeterCreated
+		require.NoError(t, proto.Unmarshal(msg.Payload, &e))
+		require.Equal(t, aggregateOf(e.Id), msg.Key)
+		ids = append(ids, e.Id)
+	}
+	return ids
+}
+
+func TestEventPublisher_InTx(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	events := NewEventPublisher(data)
+	tx := NewTransaction(data)
+	ctx := context.Background()
+
+	require.NoError(t, tx.InTx(ctx, func(ctx context.Context) error {
+		publishGreeterCreated(t, ctx, events, 1)
+		return nil
+	}))
+	rollback := errors.New("rollback")
+	require.ErrorIs(t, tx.InTx(ctx, func(ctx context.Context) error {
+		publishGreeterCreated(t, ctx, events, 11)
+		return rollback
+	}), rollback)
+
+	n, _, err := relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []int64{1}, publishedIDs(t, broker))
+	require.NotEmpty(t, broker.Messages(topicGreeterCreated)[0].ID)
+	require.Empty(t, broker.Messages(topicGreeterCreated)[0].Headers)
+
+	// Published events are not published again.
+	n, _, err = relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestEventPublisher_Headers(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(requestid.NewContext(context.Background(), "req-1"),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled}))
+	publishGreeterCreated(t, ctx, NewEventPublisher(data), 1)
+
+	_, _, err := relay.relay(ctx, nil)
+	require.NoError(t, err)
+	msgs := broker.Messages(topicGreeterCreated)
+	require.Len(t, msgs, 1)
+	require.Equal(t, map[string]string{
+		requestid.HeaderKey: "req-1",
+		"traceparent":       "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}, msgs[0].Headers)
+}
+
+func TestEventPublisher_NilPayload(t *testing.T) {
+	data, _, _, _ := newTestOutbox(t)
+	events := NewEventPublisher(data)
+	ctx := context.Background()
+
+	require.Error(t, events.Publish(ctx, &biz.Event{AggregateID: "a"}))
+	require.Error(t, events.Publish(ctx, nil))
+	var count int64
+	require.NoError(t, data.db.Model(&outboxEvent{}).Count(&count).Error)
+	require.Zero(t, count)
+}
+
+func TestOutboxRelay_RetryInOrder(t *testing.T) {
+	data, relay, broker, pub := newTestOutbox(t)
+	events := NewEventPublisher(data)
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, events, 1, 2, 11, 12)
+
+	// The failure of "a" holds back its later events, but not those of "b".
+	pub.setFail("a", true)
+	n, _, err := relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []int64{2, 12}, publishedIDs(t, broker))
+
+	var failed outboxEvent
+	require.NoError(t, data.db.Order("id").First(&failed, "aggregate_id = ?", "a").Error)
+	require.Equal(t, 1, failed.Attempts)
+	require.Equal(t, "broker unavailable", failed.LastError)
+	require.True(t, failed.NextAttemptAt.After(time.Now()))
+
+	// Until the retry is due, the later event of "a" waits even though the
+	// broker is back.
+	pub.setFail("a", false)
+	n, _, err = relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.NoError(t, data.db.Model(&failed).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	n, _, err = relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []int64{2, 12, 1, 11}, publishedIDs(t, broker))
+}
+
+func TestOutboxRelay_RetryInOrder_AcrossBatches(t *testing.T) {
+	data, relay, broker, pub := newTestOutbox(t)
+	relay.batchSize = 1
+	events := NewEventPublisher(data)
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, events, 1, 11, 2)
+
+	pub.setFail("a", true)
+	n, _, err := relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// The failed event waits for its retry outside of the batch, and its
+	// later event still waits behind it, rather than filling the batch.
+	pub.setFail("a", false)
+	n, _, err = relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []int64{2}, publishedIDs(t, broker))
+	n, _, err = relay.relay(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.NoError(t, data.db.Model(&outboxEvent{}).Where("aggregate_id = ?", "a").
+		Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	require.NoError(t, relay.poll(ctx))
+	require.Equal(t, []int64{2, 1, 11}, publishedIDs(t, broker))
+}
+
+func TestOutboxRelay_Poll_FullBatchOfFailures(t *testing.T) {
+	data, relay, broker, pub := newTestOutbox(t)
+	relay.batchSize = 2
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, NewEventPublisher(data), 1, 11, 2)
+
+	// The first batch publishes none of its events, yet was full, so the
+	// events behind it are relayed in the same poll.
+	pub.setFail("a", true)
+	require.NoError(t, relay.poll(ctx))
+	require.Equal(t, []int64{2}, publishedIDs(t, broker))
+}
+
+func TestNewOutboxRelay_Config(t *testing.T) {
+	c := &conf.Data{Outbox: &conf.Data_Outbox{
+		Interval:        durationpb.New(0),
+		RetryBackoff:    durationpb.New(-time.Second),
+		MaxRetryBackoff: durationpb.New(time.Minute),
+		Retention:       durationpb.New(0),
+	}}
+	relay := NewOutboxRelay(c, &Data{}, nil, nil, log.DefaultLogger)
+	// Durations that are not positive keep their defaults.
+	require.Equal(t, defaultOutboxInterval, relay.interval)
+	require.Equal(t, defaultOutboxRetryBackoff, relay.retryBackoff)
+	require.Equal(t, time.Minute, relay.maxRetryBackoff)
+	require.Equal(t, defaultOutboxRetention, relay.retention)
+	require.Equal(t, defaultOutboxBatchSize, relay.batchSize)
+}
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	_, relay, _, _ := newTestOutbox(t)
+
+	require.Equal(t, time.Second, relay.backoff(1))
+	require.Equal(t, 2*time.Second, relay.backoff(2))
+	require.Equal(t, 8*time.Second, relay.backoff(4))
+	require.Equal(t, 5*time.Minute, relay.backoff(100))
+}
+
+func TestOutboxRelay_Cleanup(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, NewEventPublisher(data), 1, 2)
+
+	require.NoError(t, relay.poll(ctx))
+	require.Len(t, broker.Messages(topicGreeterCreated), 2)
+
+	// Published events are kept for the retention period.
+	var count int64
+	require.NoError(t, data.db.Model(&outboxEvent{}).Count(&count).Error)
+	require.Equal(t, int64(2), count)
+
+	require.NoError(t, data.db.Model(&outboxEvent{}).Where("aggregate_id = ?", "a").
+		Update("published_at", time.Now().Add(-25*time.Hour)).Error)
+	deleted, err := relay.cleanup(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+}
+
+func TestOutboxRelay_Poll_Locked(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, NewEventPublisher(data), 1)
+
+	// Another instance is relaying.
+	lock, err := NewLocker(data).TryLock(ctx, outboxLockKey, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, relay.poll(ctx))
+	require.Empty(t, broker.Messages(topicGreeterCreated))
+
+	require.NoError(t, NewLocker(data).Unlock(ctx, lock))
+	require.NoError(t, relay.poll(ctx))
+	require.Len(t, broker.Messages(topicGreeterCreated), 1)
+}
+
+func TestOutboxRelay_Poll_ExtendLock(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	relay.batchSize = 1
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, NewEventPublisher(data), 1, 2)
+
+	// The lock is close to expiring during the first batch, and extended
+	// before the second.
+	lockKey, _ := lockKeys(outboxLockKey)
+	var ttl time.Duration
+	relay.pub = &hookPublisher{Publisher: broker, before: func(_ context.Context, n int) {
+		switch n {
+		case 1:
+			require.NoError(t, data.rdb.PExpire(ctx, lockKey, time.Second).Err())
+		case 2:
+			ttl = data.rdb.PTTL(ctx, lockKey).Val()
+		}
+	}}
+	require.NoError(t, relay.poll(ctx))
+	require.Equal(t, []int64{1, 2}, publishedIDs(t, broker))
+	require.Greater(t, ttl, time.Second)
+}
+
+func TestOutboxRelay_Poll_ExtendLockWithinBatch(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	relay.lockTTL = 200 * time.Millisecond
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, NewEventPublisher(data), 1, 2)
+
+	// The first publish takes more than half of the lock TTL, so the lock is
+	// extended before the second publish of the batch.
+	lockKey, _ := lockKeys(outboxLockKey)
+	var ttl time.Duration
+	relay.pub = &hookPublisher{Publisher: broker, before: func(pctx context.Context, n int) {
+		deadline, ok := pctx.Deadline()
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(relay.lockTTL/3), deadline, relay.lockTTL/3)
+		switch n {
+		case 1:
+			time.Sleep(relay.lockTTL / 2)
+			require.NoError(t, data.rdb.PExpire(ctx, lockKey, time.Minute).Err())
+		case 2:
+			ttl = data.rdb.PTTL(ctx, lockKey).Val()
+		}
+	}}
+	require.NoError(t, relay.poll(ctx))
+	require.Equal(t, []int64{1, 2}, publishedIDs(t, broker))
+	require.Positive(t, ttl)
+	require.LessOrEqual(t, ttl, relay.lockTTL)
+}
+
+func TestOutboxRelay_Poll_LockLost(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	relay.batchSize = 1
+	ctx := context.Background()
+	publishGreeterCreated(t, ctx, NewEventPublisher(data), 1, 2)
+
+	// The lock expires during the first batch, and another instance takes
+	// over the relay.
+	lockKey, _ := lockKeys(outboxLockKey)
+	relay.pub = &hookPublisher{Publisher: broker, before: func(context.Context, int) {
+		require.NoError(t, data.rdb.Del(ctx, lockKey).Err())
+	}}
+	require.ErrorIs(t, relay.poll(ctx), biz.ErrLockNotHeld)
+	require.Equal(t, []int64{1}, publishedIDs(t, broker))
+}
+
+func TestOutboxRelay_StartStop(t *testing.T) {
+	data, relay, broker, _ := newTestOutbox(t)
+	relay.interval = 10 * time.Millisecond
+	publishGreeterCreated(t, context.Background(), NewEventPublisher(data), 1)
+
+	errc := make(chan error, 1)
+	go func() { errc <- relay.Start(context.Background()) }()
+	require.Eventually(t, func() bool {
+		return len(broker.Messages(topicGreeterCreated)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, relay.Stop(context.Background()))
+	require.NoError(t, <-errc)
+}
@@ -0,0 +1,190 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/query"
+)
+
+// likeEscape escapes LIKE wildcards. It is not a backslash, which MySQL
+// would read as a string escape in the ESCAPE clause.
+const likeEscape = "!"
+
+// queryColumn maps a query field to a column of model T.
+type queryColumn[T any] struct {
+	name string
+	// value reads the field from a row, to build the cursor of the next page.
+	value func(*T) any
+}
+
+// listPage returns one page of the rows of T selected by q. It fetches one
+// row more than the page size to tell whether another page follows.
+func listPage[T any](db *gorm.DB, q *biz.ListQuery, columns map[string]queryColumn[T]) ([]*T, []any, error) {
+	if q.Filter != nil {
+		where, err := filterClause(q.Filter, columns)
+		if err != nil {
+			return nil, nil, err
+		}
+		db = db.Where(where)
+	}
+	if q.After != nil {
+		keyset, err := keysetClause(q.OrderBy, q.After, columns)
+		if err != nil {
+			return nil, nil, err
+		}
+		db = db.Where(keyset)
+	}
+	for _, f := range q.OrderBy {
+		col, err := lookupColumn(columns, f.Field)
+		if err != nil {
+			return nil, nil, err
+		}
+		db = db.Order(clause.OrderByColumn{Column: col, Desc: f.Desc})
+	}
+
+	var rows []*T
+	if err := db.Limit(q.PageSize + 1).Find(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+	if len(rows) <= q.PageSize {
+		return rows, nil, nil
+	}
+
+	rows = rows[:q.PageSize]
+	last := rows[len(rows)-1]
+	next := make([]any, len(q.OrderBy))
+	for i, f := range q.OrderBy {
+		next[i] = columns[f.Field].value(last)
+	}
+	return rows, next, nil
+}
+
+func lookupColumn[T any](columns map[string]queryColumn[T], field string) (clause.Column, error) {
+	c, ok := columns[field]
+	if !ok {
+		return clause.Column{}, fmt.Errorf("unsupported query field %q", field)
+	}
+	return clause.Column{Name: c.name}, nil
+}
+
+func filterClause[T any](e query.Expr, columns map[string]queryColumn[T]) (clause.Expression, error) {
+	switch e := e.(type) {
+	case query.And:
+		exprs, err := filterClauses(e, columns)
+		if err != nil {
+			return nil, err
+		}
+		return clause.And(exprs...), nil
+	case query.Or:
+		exprs, err := filterClauses(e, columns)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Or(exprs...), nil
+	case query.Not:
+		expr, err := filterClause(e.Expr, columns)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Not(expr), nil
+	case query.Compare:
+		return compareClause(e, columns)
+	default:
+		return nil, fmt.Errorf("unsupported filter expression %T", e)
+	}
+}
+
+func filterClauses[T any](es []query.Expr, columns map[string]queryColumn[T]) ([]clause.Expression, error) {
+	exprs := make([]clause.Expression, 0, len(es))
+	for _, e := range es {
+		expr, err := filterClause(e, columns)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+func compareClause[T any](c query.Compare, columns map[string]queryColumn[T]) (clause.Expression, error) {
+	col, err := lookupColumn(columns, c.Field)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.Op == query.OpHas:
+		return like(col, "%"+escapeLike(c.Value.(string))+"%"), nil
+	case c.Wildcard && c.Op == query.OpEq:
+		return like(col, wildcardPattern(c.Value.(string))), nil
+	case c.Wildcard && c.Op == query.OpNe:
+		return clause.Not(like(col, wildcardPattern(c.Value.(string)))), nil
+	}
+	return compare(col, c.Op, c.Value)
+}
+
+func compare(col clause.Column, op query.Op, v any) (clause.Expression, error) {
+	switch op {
+	case query.OpEq:
+		return clause.Eq{Column: col, Value: v}, nil
+	case query.OpNe:
+		return clause.Neq{Column: col, Value: v}, nil
+	case query.OpLt:
+		return clause.Lt{Column: col, Value: v}, nil
+	case query.OpLe:
+		return clause.Lte{Column: col, Value: v}, nil
+	case query.OpGt:
+		return clause.Gt{Column: col, Value: v}, nil
+	case query.OpGe:
+		return clause.Gte{Column: col, Value: v}, nil
+	default:
+		return nil, fmt.Errorf("unsupported filter operator %q", op)
+	}
+}
+
+func like(col clause.Column, pattern string) clause.Expression {
+	return clause.Expr{SQL: "? LIKE ? ESCAPE '" + likeEscape + "'", Vars: []any{col, pattern}}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_").Replace(s)
+}
+
+// wildcardPattern turns the * wildcards of an AIP-160 string into a LIKE pattern.
+func wildcardPattern(s string) string {
+	return strings.ReplaceAll(escapeLike(s), "*", "%")
+}
+
+// keysetClause selects the rows after the row whose orderBy values are after:
+// (a > x) OR (a = x AND b > y) OR ..., with < for descending fields.
+func keysetClause[T any](orderBy []query.OrderField, after []any, columns map[string]queryColumn[T]) (clause.Expression, error) {
+	if len(after) != len(orderBy) {
+		return nil, fmt.Errorf("cursor has %d values for %d order fields", len(after), len(orderBy))
+	}
+	ors := make([]clause.Expression, 0, len(orderBy))
+	for i, f := range orderBy {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := range i {
+			col, err := lookupColumn(columns, orderBy[j].Field)
+			if err != nil {
+				return nil, err
+			}
+			ands = append(ands, clause.Eq{Column: col, Value: after[j]})
+		}
+		col, err := lookupColumn(columns, f.Field)
+		if err != nil {
+			return nil, err
+		}
+		if f.Desc {
+			ands = append(ands, clause.Lt{Column: col, Value: after[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: col, Value: after[i]})
+		}
+		ors = append(ors, clause.And(ands...))
+	}
+	return clause.Or(ors...), nil
+}
@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/query"
+)
+
+var testGreeterSchema = query.Schema{
+	Fields: map[string]query.Kind{
+		"id":          query.Int,
+		"hello":       query.String,
+		"create_time": query.Time,
+	},
+	Key: "id",
+}
+
+// listAllPages lists every page of filter and orderBy, pageSize rows at a
+// time, passing the cursor through a page token like the service does.
+func listAllPages(t *testing.T, repo biz.GreeterRepo, filter, orderBy string, pageSize int32) [][]string {
+	t.Helper()
+	req := query.Request{PageSize: pageSize, Filter: filter, OrderBy: orderBy}
+	var pages [][]string
+	for {
+		q, err := query.Parse(testGreeterSchema, req)
+		require.NoError(t, err)
+		page, err := repo.List(context.Background(), &biz.ListQuery{
+			Filter:   q.Filter,
+			OrderBy:  q.OrderBy,
+			PageSize: q.PageSize,
+			After:    q.After,
+		})
+		require.NoError(t, err)
+		pages = append(pages, hellos(page.Items))
+
+		req.PageToken, err = q.NextPageToken(page.Next)
+		require.NoError(t, err)
+		if req.PageToken == "" {
+			return pages
+		}
+	}
+}
+
+func TestGreeterRepo_ListQuery(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+	for _, hello := range []string{"b", "a", "c", "a", "b_x", "b%"} {
+		_, err := repo.Save(ctx, &biz.Greeter{Hello: hello})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		orderBy  string
+		pageSize int32
+		want     [][]string
+	}{
+		{
+			name:     "id order",
+			pageSize: 4,
+			want:     [][]string{{"b", "a", "c", "a"}, {"b_x", "b%"}},
+		},
+		{
+			name:     "exact page",
+			pageSize: 3,
+			orderBy:  "id desc",
+			want:     [][]string{{"b%", "b_x", "a"}, {"c", "a", "b"}},
+		},
+		{
+			// Duplicate hellos are split across pages by the id tie-breaker.
+			name:     "keyset on duplicates",
+			filter:   `hello = "a" OR hello = "b" OR hello = "c"`,
+			orderBy:  "hello desc",
+			pageSize: 1,
+			want:     [][]string{{"c"}, {"b"}, {"a"}, {"a"}},
+		},
+		{
+			name:   "filter",
+			filter: `hello = "a" OR hello = "c"`,
+			want:   [][]string{{"a", "c", "a"}},
+		},
+		{
+			name:   "wildcard escapes like characters",
+			filter: `hello = "b_*"`,
+			want:   [][]string{{"b_x"}},
+		},
+		{
+			name:   "has",
+			filter: `hello:"%"`,
+			want:   [][]string{{"b%"}},
+		},
+		{
+			name:    "negation",
+			filter:  `-hello = "b*" id > 1`,
+			orderBy: "hello",
+			want:    [][]string{{"a", "a", "c"}},
+		},
+		{
+			name:   "no rows",
+			filter: `hello = "missing"`,
+			want:   [][]string{{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, listAllPages(t, repo, tt.filter, tt.orderBy, tt.pageSize))
+		})
+	}
+}
+
+func TestGreeterRepo_ListQuery_TimeKeyset(t *testing.T) {
+	repo := newTestGreeterRepo(t)
+	ctx := context.Background()
+	for _, hello := range []string{"first", "second", "third"} {
+		_, err := repo.Save(ctx, &biz.Greeter{Hello: hello})
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	pages := listAllPages(t, repo, "", "create_time desc", 1)
+	require.Equal(t, [][]string{{"third"}, {"second"}, {"first"}}, pages)
+}
@@ -0,0 +1,110 @@
+package data
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+)
+
+// Redis modes of conf.Data.Redis.
+const (
+	RedisModeSingle   = "single"
+	RedisModeSentinel = "sentinel"
+	RedisModeCluster  = "cluster"
+)
+
+// NewRedisClient creates a Redis client for the configured mode.
+func NewRedisClient(c *conf.Data_Redis) (redis.UniversalClient, error) {
+	tlsConfig, err := newRedisTLSConfig(c.Tls)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mode := c.Mode; mode {
+	case "", RedisModeSingle:
+		if c.Addr == "" {
+			return nil, fmt.Errorf("redis addr is required in %s mode", RedisModeSingle)
+		}
+		return redis.NewClient(&redis.Options{
+			Network:      c.Network,
+			Addr:         c.Addr,
+			Username:     c.Username,
+			Password:     c.Password,
+			DB:           int(c.Db),
+			DialTimeout:  c.DialTimeout.AsDuration(),
+			ReadTimeout:  c.ReadTimeout.AsDuration(),
+			WriteTimeout: c.WriteTimeout.AsDuration(),
+			PoolSize:     int(c.PoolSize),
+			TLSConfig:    tlsConfig,
+		}), nil
+	case RedisModeSentinel:
+		if c.MasterName == "" || len(c.SentinelAddrs) == 0 {
+			return nil, fmt.Errorf("redis master_name and sentinel_addrs are required in %s mode", RedisModeSentinel)
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       c.MasterName,
+			SentinelAddrs:    c.SentinelAddrs,
+			SentinelPassword: c.SentinelPassword,
+			Username:         c.Username,
+			Password:         c.Password,
+			DB:               int(c.Db),
+			DialTimeout:      c.DialTimeout.AsDuration(),
+			ReadTimeout:      c.ReadTimeout.AsDuration(),
+			WriteTimeout:     c.WriteTimeout.AsDuration(),
+			PoolSize:         int(c.PoolSize),
+			TLSConfig:        tlsConfig,
+		}), nil
+	case RedisModeCluster:
+		if len(c.ClusterAddrs) == 0 {
+			return nil, fmt.Errorf("redis cluster_addrs are required in %s mode", RedisModeCluster)
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        c.ClusterAddrs,
+			Username:     c.Username,
+			Password:     c.Password,
+			DialTimeout:  c.DialTimeout.AsDuration(),
+			ReadTimeout:  c.ReadTimeout.AsDuration(),
+			WriteTimeout: c.WriteTimeout.AsDuration(),
+			PoolSize:     int(c.PoolSize),
+			TLSConfig:    tlsConfig,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q", mode)
+	}
+}
+
+func newRedisTLSConfig(c *conf.Data_Redis_TLS) (*tls.Config, error) {
+	if !c.GetEnabled() {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // opt-in for test environments
+	}
+	if c.CaFile != "" {
+		pem, err := os.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("read redis ca file failed: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("redis ca file %s contains no certificates", c.CaFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load redis client certificate failed: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/internal/conf"
+)
+
+func TestNewRedisClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *conf.Data_Redis
+		want    any
+		wantErr string
+	}{
+		{
+			name: "single by default",
+			conf: &conf.Data_Redis{Addr: "127.0.0.1:6379"},
+			want: &redis.Client{},
+		},
+		{
+			name:    "single without addr",
+			conf:    &conf.Data_Redis{Mode: RedisModeSingle},
+			wantErr: "redis addr is required",
+		},
+		{
+			name: "sentinel",
+			conf: &conf.Data_Redis{
+				Mode:          RedisModeSentinel,
+				MasterName:    "mymaster",
+				SentinelAddrs: []string{"127.0.0.1:26379"},
+			},
+			want: &redis.Client{},
+		},
+		{
+			name:    "sentinel without master name",
+			conf:    &conf.Data_Redis{Mode: RedisModeSentinel, SentinelAddrs: []string{"127.0.0.1:26379"}},
+			wantErr: "master_name and sentinel_addrs are required",
+		},
+		{
+			name: "cluster",
+			conf: &conf.Data_Redis{
+				Mode:         RedisModeCluster,
+				ClusterAddrs: []string{"127.0.0.1:7000", "127.0.0.1:7001"},
+			},
+			want: &redis.ClusterClient{},
+		},
+		{
+			name:    "cluster without addrs",
+			conf:    &conf.Data_Redis{Mode: RedisModeCluster},
+			wantErr: "cluster_addrs are required",
+		},
+		{
+			name:    "unknown mode",
+			conf:    &conf.Data_Redis{Mode: "ring", Addr: "127.0.0.1:6379"},
+			wantErr: `unknown redis mode "ring"`,
+		},
+		{
+			name: "missing ca file",
+			conf: &conf.Data_Redis{
+				Addr: "127.0.0.1:6379",
+				Tls:  &conf.Data_Redis_TLS{Enabled: true, CaFile: "testdata/missing.pem"},
+			},
+			wantErr: "read redis ca file failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdb, err := NewRedisClient(tt.conf)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = rdb.Close() })
+			require.IsType(t, tt.want, rdb)
+		})
+	}
+}
+
+func TestNewRedisClient_Single(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.RequireUserAuth("app", "secret")
+
+	rdb, err := NewRedisClient(&conf.Data_Redis{
+		Network:  "tcp",
+		Addr:     mr.Addr(),
+		Username: "app",
+		Password: "secret",
+		PoolSize: 2,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	require.NoError(t, rdb.Ping(context.Background()).Err())
+	require.Equal(t, 2, rdb.(*redis.Client).Options().PoolSize)
+}
+
+func TestNewRedisTLSConfig(t *testing.T) {
+	cfg, err := newRedisTLSConfig(nil)
+	require.NoError(t, err)
+	require.Nil(t, cfg)
+
+	cfg, err = newRedisTLSConfig(&conf.Data_Redis_TLS{Enabled: true, ServerName: "redis.internal"})
+	require.NoError(t, err)
+	require.Equal(t, "redis.internal", cfg.ServerName)
+	require.Nil(t, cfg.RootCAs)
+
+	invalid := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0o600))
+	_, err = newRedisTLSConfig(&conf.Data_Redis_TLS{Enabled: true, CaFile: invalid})
+	require.ErrorContains(t, err, "contains no certificates")
+
+	_, err = newRedisTLSConfig(&conf.Data_Redis_TLS{Enabled: true, CertFile: "testdata/missing.pem"})
+	require.ErrorContains(t, err, "load redis client certificate failed")
+}
@@ -2,33 +2,50 @@ package data
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 
 	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/data/migrations"
 	"github.com/go-kratos/kratos-layout/pkg/orm"
 )
 
 // LocalConfigFileName is the name of the local config file for testing.
 const LocalConfigFileName = ".local.config.yaml"
 
+// ErrLocalConfigNotFound is returned by NewTestSuiteFromLocal when configs/.local.config.yaml does not exist.
+var ErrLocalConfigNotFound = errors.New("local config file not found")
+
 // TestSuite provides test utilities for data layer testing.
-// It manages MySQL and Redis connections and provides cleanup methods.
+// It manages database and Redis connections and provides cleanup methods.
 type TestSuite struct {
 	conf    *conf.Data
 	db      orm.DB
 	utilDB  orm.DBUtil
-	redis   *redis.Client
-	cleanup []func()
+	redis   redis.UniversalClient
+	cleanup []func() error
+
+	// inMemory is set for suites created by NewInMemoryTestSuite.
+	inMemory bool
+	// dropDB drops the database on TearDown, for suites created by ForTest.
+	dropDB bool
+	// keyPrefix is prepended to Redis keys, for cluster suites created by ForTest.
+	keyPrefix string
 }
 
 // NewTestSuiteFromLocal creates a new test suite loading config from configs/.local.config.yaml
@@ -59,7 +76,30 @@ func NewTestSuiteFromLocal() (*TestSuite, error) {
 
 	return &TestSuite{
 		conf:    bc.Data,
-		cleanup: make([]func(), 0),
+		cleanup: make([]func() error, 0),
+	}, nil
+}
+
+// NewInMemoryTestSuite creates a test suite backed by an in-memory SQLite
+// database and an embedded miniredis server, so it needs no external services.
+func NewInMemoryTestSuite() (*TestSuite, error) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		return nil, fmt.Errorf("start miniredis failed: %w", err)
+	}
+
+	return &TestSuite{
+		conf: &conf.Data{
+			Database: &conf.Data_Database{
+				Driver: orm.DriverSQLite,
+				DbName: orm.SQLiteMemory,
+			},
+			Redis: &conf.Data_Redis{
+				Addr: mr.Addr(),
+			},
+		},
+		cleanup:  []func() error{closeMiniredis(mr)},
+		inMemory: true,
 	}, nil
 }
 
@@ -75,21 +115,25 @@ func findLocalConfig() (string, error) {
 	configPath := filepath.Join(projectRoot, "configs", LocalConfigFileName)
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("local config file not found: %s\nPlease create configs/%s for local testing", configPath, LocalConfigFileName)
+		return "", fmt.Errorf("%w: %s\nPlease create configs/%s for local testing", ErrLocalConfigNotFound, configPath, LocalConfigFileName)
 	}
 
 	return configPath, nil
 }
 
-// Setup initializes all database connections.
+// Setup initializes all database connections and applies migrations.
 // Call this in TestMain or at the beginning of your test.
 func (ts *TestSuite) Setup() error {
 	if err := ts.validateConfig(); err != nil {
 		return err
 	}
 
-	if err := ts.setupMySQL(); err != nil {
-		return fmt.Errorf("setup mysql failed: %w", err)
+	if err := ts.setupDB(); err != nil {
+		return fmt.Errorf("setup database failed: %w", err)
+	}
+
+	if err := ts.Migrate(); err != nil {
+		return fmt.Errorf("migrate database failed: %w", err)
 	}
 
 	if err := ts.setupRedis(); err != nil {
@@ -108,9 +152,13 @@ func (ts *TestSuite) TearDown() error {
 		errs = append(errs, fmt.Errorf("clear all data failed: %w", err))
 	}
 
-	for _, fn := range ts.cleanup {
-		fn()
+	// Release resources in reverse order of acquisition.
+	for i := len(ts.cleanup) - 1; i >= 0; i-- {
+		if err := ts.cleanup[i](); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	ts.cleanup = nil
 
 	if len(errs) > 0 {
 		return fmt.Errorf("teardown errors: %v", errs)
@@ -129,12 +177,12 @@ func (ts *TestSuite) ClearAll() error {
 	return nil
 }
 
-// ClearMySQL clears all data from MySQL tables.
+// ClearMySQL truncates the database tables, keeping the applied migrations.
 func (ts *TestSuite) ClearMySQL() error {
 	if ts.db == nil {
 		return nil
 	}
-	return ts.db.ClearAllData()
+	return ts.db.ClearAllData(orm.WithTruncate(), orm.WithExcludeTables(orm.DefaultMigrationTable))
 }
 
 // ClearRedis clears all data from Redis.
@@ -146,6 +194,9 @@ func (ts *TestSuite) ClearRedis() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if cluster, ok := ts.redis.(*redis.ClusterClient); ok {
+		return ts.clearRedisCluster(ctx, cluster)
+	}
 	return ts.redis.FlushDB(ctx).Err()
 }
 
@@ -157,8 +208,17 @@ func (ts *TestSuite) DB() *gorm.DB {
 	return ts.db.GetDB()
 }
 
-// Redis returns the redis.Client instance for test operations.
-func (ts *TestSuite) Redis() *redis.Client {
+// LoadFixtures inserts the rows of the YAML or JSON fixture files at paths in
+// one transaction. See orm.LoadFixtures for the file format.
+func (ts *TestSuite) LoadFixtures(paths ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return orm.LoadFixtures(ctx, ts.DB(), paths...)
+}
+
+// Redis returns the Redis client for test operations.
+func (ts *TestSuite) Redis() redis.UniversalClient {
 	return ts.redis
 }
 
@@ -168,6 +228,9 @@ func (ts *TestSuite) validateConfig() error {
 	}
 
 	dbName := ts.conf.Database.DbName
+	if ts.conf.Database.Driver == orm.DriverSQLite && dbName == orm.SQLiteMemory {
+		return nil
+	}
 	if !strings.Contains(dbName, "test") && !strings.Contains(dbName, "dev") {
 		return fmt.Errorf("database name must contain 'test' or 'dev' for safety, got: %s", dbName)
 	}
@@ -175,20 +238,8 @@ func (ts *TestSuite) validateConfig() error {
 	return nil
 }
 
-func (ts *TestSuite) setupMySQL() error {
-	db := ts.conf.Database
-	dbConfig := &orm.DBConfig{
-		Username:        db.Username,
-		Password:        db.Password,
-		Host:            db.Host,
-		Port:            fmt.Sprintf("%d", db.Port),
-		DBName:          db.DbName,
-		DBCharset:       db.DbCharset,
-		MaxIdleConns:    int(db.MaxIdleConns),
-		MaxOpenConns:    int(db.MaxOpenConns),
-		ConnMaxLifetime: db.ConnMaxLifetime.AsDuration(),
-		ConnMaxIdleTime: db.ConnMaxIdleTime.AsDuration(),
-	}
+func (ts *TestSuite) setupDB() error {
+	dbConfig := NewDBConfig(ts.conf.Database)
 
 	// Create util DB for database management
 	utilDB, err := orm.MakeDBUtil(dbConfig)
@@ -196,12 +247,15 @@ func (ts *TestSuite) setupMySQL() error {
 		return fmt.Errorf("create util db failed: %w", err)
 	}
 	ts.utilDB = utilDB
-	ts.cleanup = append(ts.cleanup, func() { utilDB.Close() })
+	ts.cleanup = append(ts.cleanup, utilDB.Close)
 
 	// Create database if not exists
 	if createErr := utilDB.CreateDB(); createErr != nil {
 		return fmt.Errorf("create database failed: %w", createErr)
 	}
+	if ts.dropDB {
+		ts.cleanup = append(ts.cleanup, utilDB.DropDB)
+	}
 
 	// Create main DB connection
 	ormDB, err := orm.MakeDB(dbConfig)
@@ -209,26 +263,39 @@ func (ts *TestSuite) setupMySQL() error {
 		return fmt.Errorf("create db failed: %w", err)
 	}
 	ts.db = ormDB
-	ts.cleanup = append(ts.cleanup, func() { ormDB.Close() })
+	ts.cleanup = append(ts.cleanup, ormDB.Close)
+
+	return nil
+}
+
+// Migrate applies all pending migrations to the test database.
+func (ts *TestSuite) Migrate() error {
+	migrator, err := orm.NewMigrator(ts.db.GetDB(), migrations.FS, migrations.Dir(ts.conf.Database.Driver))
+	if err != nil {
+		return fmt.Errorf("create migrator failed: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
 
+	if _, err := migrator.Up(ctx, 0); err != nil {
+		return fmt.Errorf("apply migrations failed: %w", err)
+	}
 	return nil
 }
 
 func (ts *TestSuite) setupRedis() error {
-	if ts.conf.Redis == nil || ts.conf.Redis.Addr == "" {
+	c := ts.conf.Redis
+	if !redisConfigured(c) {
 		return nil // Redis is optional
 	}
 
-	redisCfg := ts.conf.Redis
-	ts.redis = redis.NewClient(&redis.Options{
-		Addr:         redisCfg.Addr,
-		Password:     redisCfg.Password,
-		DB:           int(redisCfg.Db),
-		DialTimeout:  redisCfg.DialTimeout.AsDuration(),
-		ReadTimeout:  redisCfg.ReadTimeout.AsDuration(),
-		WriteTimeout: redisCfg.WriteTimeout.AsDuration(),
-	})
-	ts.cleanup = append(ts.cleanup, func() { ts.redis.Close() })
+	rdb, err := NewRedisClient(c)
+	if err != nil {
+		return err
+	}
+	ts.redis = rdb
+	ts.cleanup = append(ts.cleanup, ts.redis.Close)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -237,5 +304,153 @@ func (ts *TestSuite) setupRedis() error {
 		return fmt.Errorf("ping redis failed: %w", err)
 	}
 
+	if ts.keyPrefix != "" {
+		hook, err := newKeyPrefixHook(ctx, ts.redis, ts.keyPrefix)
+		if err != nil {
+			return err
+		}
+		ts.redis.AddHook(hook)
+	}
 	return nil
 }
+
+// redisConfigured reports whether c configures a Redis of any mode.
+func redisConfigured(c *conf.Data_Redis) bool {
+	return c != nil && (c.Addr != "" || len(c.SentinelAddrs) > 0 || len(c.ClusterAddrs) > 0)
+}
+
+// TB is the part of testing.TB used by ForTest. It keeps the testing package
+// out of the binaries importing this package.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// testDBSeq numbers the databases created by ForTest within this process.
+var testDBSeq atomic.Int64
+
+// ForTest returns a suite with a database of its own for t, so that tests
+// using it can call t.Parallel. The database is created next to the suite's
+// database, migrated, and dropped when t finishes. Redis gets a dedicated DB
+// index, or a dedicated miniredis server for an in-memory suite. Cluster mode
+// has no DB indexes, so there every key gets a prefix unique to t instead.
+func (ts *TestSuite) ForTest(t TB) *TestSuite {
+	t.Helper()
+
+	child := &TestSuite{
+		conf:     proto.Clone(ts.conf).(*conf.Data),
+		inMemory: ts.inMemory,
+		dropDB:   true,
+	}
+	seq := testDBSeq.Add(1)
+	child.conf.Database.DbName = isolatedDBName(child.conf.Database, seq)
+	t.Cleanup(func() {
+		if err := child.TearDown(); err != nil {
+			t.Errorf("teardown test suite failed: %v", err)
+		}
+	})
+
+	if err := child.validateConfig(); err != nil {
+		t.Fatalf("invalid test suite config: %v", err)
+	}
+	if err := child.setupDB(); err != nil {
+		t.Fatalf("setup database failed: %v", err)
+	}
+	if err := child.Migrate(); err != nil {
+		t.Fatalf("migrate database failed: %v", err)
+	}
+
+	if redisConfigured(child.conf.Redis) {
+		switch {
+		case child.inMemory:
+			mr, err := miniredis.Run()
+			if err != nil {
+				t.Fatalf("start miniredis failed: %v", err)
+			}
+			child.cleanup = append(child.cleanup, closeMiniredis(mr))
+			child.conf.Redis.Addr = mr.Addr()
+		case child.conf.Redis.Mode == RedisModeCluster:
+			child.keyPrefix = fmt.Sprintf("test:%d:%d:", os.Getpid(), seq)
+		default:
+			db, release := ts.acquireRedisDB(t)
+			child.cleanup = append(child.cleanup, release)
+			child.conf.Redis.Db = db
+		}
+		if err := child.setupRedis(); err != nil {
+			t.Fatalf("setup redis failed: %v", err)
+		}
+		// The DB index or prefix may hold keys left by an aborted run.
+		if err := child.ClearRedis(); err != nil {
+			t.Fatalf("clear redis failed: %v", err)
+		}
+	}
+
+	return child
+}
+
+// isolatedDBName derives a database name unique to this process and seq.
+func isolatedDBName(c *conf.Data_Database, seq int64) string {
+	suffix := fmt.Sprintf("_%d_%d", os.Getpid(), seq)
+	if c.Driver != orm.DriverSQLite {
+		return c.DbName + suffix
+	}
+	if c.DbName == orm.SQLiteMemory {
+		// Every in-memory connection pool is a database of its own.
+		return c.DbName
+	}
+	ext := filepath.Ext(c.DbName)
+	return strings.TrimSuffix(c.DbName, ext) + suffix + ext
+}
+
+// redisDBPool hands out the Redis DB indexes not used by any suite from NewTestSuiteFromLocal.
+var (
+	redisDBPoolOnce sync.Once
+	redisDBPool     chan int32
+)
+
+// acquireRedisDB reserves a Redis DB index other than the suite's own until
+// the returned release function is called. It waits for a free index when all
+// are in use by parallel tests.
+func (ts *TestSuite) acquireRedisDB(t TB) (int32, func() error) {
+	t.Helper()
+
+	redisDBPoolOnce.Do(func() {
+		databases := 16
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if ts.redis != nil {
+			if res, err := ts.redis.ConfigGet(ctx, "databases").Result(); err == nil {
+				if n, err := strconv.Atoi(res["databases"]); err == nil && n > 0 {
+					databases = n
+				}
+			}
+		}
+
+		redisDBPool = make(chan int32, databases)
+		for i := int32(0); i < int32(databases); i++ {
+			if i != ts.conf.Redis.Db {
+				redisDBPool <- i
+			}
+		}
+	})
+
+	select {
+	case db := <-redisDBPool:
+		return db, func() error {
+			redisDBPool <- db
+			return nil
+		}
+	case <-time.After(time.Minute):
+		t.Fatalf("no free redis db index, reduce -parallel")
+		return 0, nil
+	}
+}
+
+func closeMiniredis(mr *miniredis.Miniredis) func() error {
+	return func() error {
+		mr.Close()
+		return nil
+	}
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefixHook prefixes the keys of the commands a Redis client sends, so
+// that tests sharing a Redis Cluster keep to keyspaces of their own. Key
+// positions come from COMMAND, except for the commands with movable keys used
+// here. Keys returned by commands such as SCAN keep the prefix.
+type keyPrefixHook struct {
+	prefix string
+	info   map[string]*redis.CommandInfo
+}
+
+func newKeyPrefixHook(ctx context.Context, rdb redis.UniversalClient, prefix string) (*keyPrefixHook, error) {
+	info, err := rdb.Command(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get redis command info failed: %w", err)
+	}
+	return &keyPrefixHook{prefix: prefix, info: info}, nil
+}
+
+func (h *keyPrefixHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *keyPrefixHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.prefixKeys(cmd)
+		return next(ctx, cmd)
+	}
+}
+
+func (h *keyPrefixHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			h.prefixKeys(cmd)
+		}
+		return next(ctx, cmds)
+	}
+}
+
+func (h *keyPrefixHook) prefixKeys(cmd redis.Cmder) {
+	args := cmd.Args()
+	switch name := strings.ToLower(cmd.Name()); name {
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		// EVAL script numkeys key [key ...] arg [arg ...]
+		if len(args) < 3 {
+			return
+		}
+		n, _ := strconv.Atoi(fmt.Sprint(args[2]))
+		h.prefixRange(args, 3, 2+n, 1)
+	case "xread", "xreadgroup":
+		// XREAD ... STREAMS key [key ...] id [id ...]
+		for i, arg := range args {
+			if s, ok := arg.(string); ok && strings.EqualFold(s, "streams") {
+				n := (len(args) - i - 1) / 2
+				h.prefixRange(args, i+1, i+n, 1)
+				return
+			}
+		}
+	case "xgroup", "xinfo":
+		// XGROUP CREATE key group id, XINFO STREAM key
+		h.prefixRange(args, 2, 2, 1)
+	default:
+		info := h.info[name]
+		if info == nil || info.FirstKeyPos <= 0 {
+			return
+		}
+		last := int(info.LastKeyPos)
+		if last < 0 {
+			last += len(args)
+		}
+		h.prefixRange(args, int(info.FirstKeyPos), last, max(int(info.StepCount), 1))
+	}
+}
+
+// prefixRange prefixes the args from first to last, every step.
+func (h *keyPrefixHook) prefixRange(args []any, first, last, step int) {
+	for i := first; i <= last && i < len(args); i += step {
+		switch key := args[i].(type) {
+		case string:
+			args[i] = h.prefix + key
+		case []byte:
+			args[i] = append([]byte(h.prefix), key...)
+		}
+	}
+}
+
+// clearRedisCluster empties every master of rdb, or only deletes the keys
+// of the suite's prefix when it has one.
+func (ts *TestSuite) clearRedisCluster(ctx context.Context, rdb *redis.ClusterClient) error {
+	return rdb.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		if ts.keyPrefix == "" {
+			return node.FlushDB(ctx).Err()
+		}
+		// Node clients do not run the prefix hook of the cluster client.
+		iter := node.Scan(ctx, 0, ts.keyPrefix+"*", 100).Iterator()
+		for iter.Next(ctx) {
+			if err := node.Del(ctx, iter.Val()).Err(); err != nil {
+				return err
+			}
+		}
+		return iter.Err()
+	})
+}
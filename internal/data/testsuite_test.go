@@ -2,11 +2,20 @@ package data
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
 )
 
 var testSuite *TestSuite
@@ -14,6 +23,10 @@ var testSuite *TestSuite
 func TestMain(m *testing.M) {
 	var err error
 	testSuite, err = NewTestSuiteFromLocal()
+	if errors.Is(err, ErrLocalConfigNotFound) {
+		// Without a local config, run against SQLite and miniredis.
+		testSuite, err = NewInMemoryTestSuite()
+	}
 	if err != nil {
 		panic("failed to load test config: " + err.Error())
 	}
@@ -77,3 +90,137 @@ func TestTestSuite_ClearRedis(t *testing.T) {
 	_, err = rdb.Get(ctx, "clear_test_key").Result()
 	require.Error(t, err)
 }
+
+func TestNewInMemoryTestSuite(t *testing.T) {
+	ts, err := NewInMemoryTestSuite()
+	require.NoError(t, err)
+	require.NoError(t, ts.Setup())
+	defer func() {
+		require.NoError(t, ts.TearDown())
+	}()
+
+	require.True(t, ts.DB().Migrator().HasTable(&Greeter{}))
+	require.NoError(t, ts.DB().Create(&Greeter{Hello: "memory"}).Error)
+
+	ctx := context.Background()
+	require.NoError(t, ts.Redis().Set(ctx, "memory_key", "value", time.Minute).Err())
+
+	require.NoError(t, ts.ClearAll())
+
+	var count int64
+	require.NoError(t, ts.DB().Model(&Greeter{}).Count(&count).Error)
+	require.Zero(t, count)
+	require.Zero(t, ts.Redis().Exists(ctx, "memory_key").Val())
+}
+
+func TestTestSuite_ForTest(t *testing.T) {
+	names := make(chan string, 3)
+	t.Run("group", func(t *testing.T) {
+		for _, hello := range []string{"a", "b", "c"} {
+			t.Run(hello, func(t *testing.T) {
+				t.Parallel()
+				ts := testSuite.ForTest(t)
+				names <- ts.conf.Database.DbName
+
+				ctx := context.Background()
+				repo := NewGreeterRepo(&Data{db: ts.DB(), rdb: ts.Redis()}, log.DefaultLogger)
+				_, err := repo.Save(ctx, &biz.Greeter{Hello: hello})
+				require.NoError(t, err)
+
+				all, err := repo.ListAll(ctx)
+				require.NoError(t, err)
+				require.Len(t, all, 1)
+				require.Equal(t, hello, all[0].Hello)
+
+				require.NoError(t, ts.Redis().Set(ctx, "shared_key", hello, time.Minute).Err())
+				val, err := ts.Redis().Get(ctx, "shared_key").Result()
+				require.NoError(t, err)
+				require.Equal(t, hello, val)
+			})
+		}
+	})
+	close(names)
+
+	if testSuite.inMemory {
+		return
+	}
+	seen := make(map[string]bool)
+	for name := range names {
+		require.NotEqual(t, testSuite.conf.Database.DbName, name)
+		require.False(t, seen[name])
+		seen[name] = true
+	}
+}
+
+func TestTestSuite_ForTest_Cluster(t *testing.T) {
+	// miniredis serves as a single node cluster.
+	mr := miniredis.RunT(t)
+	parent := &TestSuite{conf: &conf.Data{
+		Database: &conf.Data_Database{Driver: orm.DriverSQLite, DbName: orm.SQLiteMemory},
+		Redis:    &conf.Data_Redis{Mode: RedisModeCluster, ClusterAddrs: []string{mr.Addr()}},
+	}}
+	a, b := parent.ForTest(t), parent.ForTest(t)
+	require.NotEmpty(t, a.keyPrefix)
+	require.NotEqual(t, a.keyPrefix, b.keyPrefix)
+
+	ctx := context.Background()
+	require.NoError(t, a.Redis().Set(ctx, "shared_key", "a", time.Minute).Err())
+	require.NoError(t, b.Redis().Set(ctx, "shared_key", "b", time.Minute).Err())
+	val, err := a.Redis().Get(ctx, "shared_key").Result()
+	require.NoError(t, err)
+	require.Equal(t, "a", val)
+
+	// Scripts and stream commands with movable keys are prefixed too.
+	lock, err := NewLocker(&Data{rdb: a.Redis()}).TryLock(ctx, "lock", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, NewLocker(&Data{rdb: a.Redis()}).Unlock(ctx, lock))
+	require.NoError(t, a.Redis().XGroupCreateMkStream(ctx, "stream", "group", "$").Err())
+	require.NoError(t, a.Redis().XAdd(ctx, &redis.XAddArgs{Stream: "stream", Values: []any{"k", "v"}}).Err())
+	res, err := a.Redis().XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group: "group", Consumer: "c", Streams: []string{"stream", ">"}, Count: 1,
+	}).Result()
+	require.NoError(t, err)
+	require.Len(t, res[0].Messages, 1)
+
+	require.ElementsMatch(t, []string{
+		a.keyPrefix + "shared_key", a.keyPrefix + "lock:{lock}:fence", a.keyPrefix + "stream",
+		b.keyPrefix + "shared_key",
+	}, mr.Keys())
+
+	// Clearing a suite leaves the keys of other tests.
+	require.NoError(t, a.ClearRedis())
+	require.Equal(t, []string{b.keyPrefix + "shared_key"}, mr.Keys())
+}
+
+func TestIsolatedDBName(t *testing.T) {
+	suffix := fmt.Sprintf("_%d_7", os.Getpid())
+	tests := []struct {
+		name     string
+		conf     *conf.Data_Database
+		expected string
+	}{
+		{"mysql", &conf.Data_Database{DbName: "app_test"}, "app_test" + suffix},
+		{"postgres", &conf.Data_Database{Driver: orm.DriverPostgres, DbName: "app_test"}, "app_test" + suffix},
+		{"sqlite file", &conf.Data_Database{Driver: orm.DriverSQLite, DbName: "/tmp/app_test.db"}, "/tmp/app_test" + suffix + ".db"},
+		{"sqlite memory", &conf.Data_Database{Driver: orm.DriverSQLite, DbName: orm.SQLiteMemory}, orm.SQLiteMemory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isolatedDBName(tt.conf, 7))
+		})
+	}
+}
+
+func TestTestSuite_ClearMySQL_KeepsMigrations(t *testing.T) {
+	ts := testSuite.ForTest(t)
+	require.NoError(t, ts.DB().Create(&Greeter{Hello: "a"}).Error)
+
+	require.NoError(t, ts.ClearMySQL())
+
+	var greeters, migrations int64
+	require.NoError(t, ts.DB().Model(&Greeter{}).Count(&greeters).Error)
+	require.NoError(t, ts.DB().Table(orm.DefaultMigrationTable).Count(&migrations).Error)
+	require.Zero(t, greeters)
+	require.NotZero(t, migrations)
+}
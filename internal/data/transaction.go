@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+type (
+	txKey      struct{}
+	txHooksKey struct{}
+)
+
+// txHooks collects the functions to run once a transaction commits.
+type txHooks struct {
+	mu          sync.Mutex
+	afterCommit []func(context.Context)
+}
+
+func (h *txHooks) add(fns ...func(context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.afterCommit = append(h.afterCommit, fns...)
+}
+
+func (h *txHooks) take() []func(context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fns := h.afterCommit
+	h.afterCommit = nil
+	return fns
+}
+
+type transaction struct {
+	data *Data
+}
+
+// NewTransaction creates a biz.Transaction backed by the data layer database.
+func NewTransaction(data *Data) biz.Transaction {
+	return &transaction{data: data}
+}
+
+// InTx implements biz.Transaction. gorm turns a Transaction call on an
+// existing transaction into a savepoint, which gives nested calls their own rollback scope.
+func (t *transaction) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	hooks := &txHooks{}
+	err := t.data.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(context.WithValue(ctx, txKey{}, tx), txHooksKey{}, hooks))
+	})
+	if err != nil {
+		// A deadlock may only surface on commit.
+		return dbError(err, nil, "", 0)
+	}
+	if parent, ok := ctx.Value(txHooksKey{}).(*txHooks); ok {
+		// A nested transaction is only committed with the outer one.
+		parent.add(hooks.take()...)
+		return nil
+	}
+	for _, fn := range hooks.take() {
+		fn(ctx)
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction carried by ctx commits, or right
+// away when ctx carries none. fn is dropped when the transaction, or the
+// nested one it was registered in, rolls back. It receives the context of the
+// outermost InTx call.
+func (d *Data) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(txHooksKey{}).(*txHooks); ok {
+		hooks.add(fn)
+		return
+	}
+	fn(ctx)
+}
+
+// DB returns the transaction carried by ctx, or the default database when
+// ctx carries none. Repositories must use it instead of the db field so that
+// they join transactions started by biz.Transaction. Reads outside a
+// transaction go to a replica unless ctx was marked with orm.WithPrimary.
+func (d *Data) DB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	if orm.IsPrimary(ctx) {
+		return orm.UsePrimary(d.db.WithContext(ctx))
+	}
+	return d.db.WithContext(ctx)
+}
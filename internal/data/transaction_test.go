@@ -0,0 +1,173 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/orm"
+)
+
+func newTestTransaction(t *testing.T) (biz.Transaction, biz.GreeterRepo) {
+	t.Helper()
+	require.NoError(t, testSuite.ClearAll())
+	t.Cleanup(func() {
+		require.NoError(t, testSuite.ClearAll())
+	})
+	data := &Data{db: testSuite.DB(), rdb: testSuite.Redis()}
+	return NewTransaction(data), NewGreeterRepo(data, log.DefaultLogger)
+}
+
+func countGreeters(t *testing.T, repo biz.GreeterRepo) int {
+	t.Helper()
+	all, err := repo.ListAll(context.Background())
+	require.NoError(t, err)
+	return len(all)
+}
+
+func TestTransaction_Commit(t *testing.T) {
+	tx, repo := newTestTransaction(t)
+
+	err := tx.InTx(context.Background(), func(ctx context.Context) error {
+		if _, err := repo.Save(ctx, &biz.Greeter{Hello: "a"}); err != nil {
+			return err
+		}
+		_, err := repo.Save(ctx, &biz.Greeter{Hello: "b"})
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, countGreeters(t, repo))
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	tx, repo := newTestTransaction(t)
+	errBoom := errors.New("boom")
+
+	err := tx.InTx(context.Background(), func(ctx context.Context) error {
+		if _, err := repo.Save(ctx, &biz.Greeter{Hello: "a"}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	require.ErrorIs(t, err, errBoom)
+	require.Equal(t, 0, countGreeters(t, repo))
+}
+
+func TestTransaction_Panic(t *testing.T) {
+	tx, repo := newTestTransaction(t)
+
+	require.PanicsWithValue(t, "boom", func() {
+		_ = tx.InTx(context.Background(), func(ctx context.Context) error {
+			if _, err := repo.Save(ctx, &biz.Greeter{Hello: "a"}); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	})
+	require.Equal(t, 0, countGreeters(t, repo))
+}
+
+func TestTransaction_Nested(t *testing.T) {
+	tx, repo := newTestTransaction(t)
+	errInner := errors.New("inner")
+
+	err := tx.InTx(context.Background(), func(ctx context.Context) error {
+		if _, err := repo.Save(ctx, &biz.Greeter{Hello: "outer"}); err != nil {
+			return err
+		}
+
+		// The failed inner call only rolls back to its savepoint.
+		innerErr := tx.InTx(ctx, func(ctx context.Context) error {
+			if _, err := repo.Save(ctx, &biz.Greeter{Hello: "inner"}); err != nil {
+				return err
+			}
+			return errInner
+		})
+		require.ErrorIs(t, innerErr, errInner)
+
+		return tx.InTx(ctx, func(ctx context.Context) error {
+			_, err := repo.Save(ctx, &biz.Greeter{Hello: "inner-ok"})
+			return err
+		})
+	})
+	require.NoError(t, err)
+
+	all, err := repo.ListAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, "outer", all[0].Hello)
+	require.Equal(t, "inner-ok", all[1].Hello)
+}
+
+func TestData_DB_OutsideTransaction(t *testing.T) {
+	data := &Data{db: testSuite.DB()}
+	require.NotNil(t, data.DB(context.Background()))
+}
+
+func TestData_DB_Primary(t *testing.T) {
+	data := &Data{db: testSuite.DB()}
+
+	_, ok := data.DB(context.Background()).Statement.Settings.Load("gorm:db_resolver:write")
+	require.False(t, ok)
+
+	_, ok = data.DB(orm.WithPrimary(context.Background())).Statement.Settings.Load("gorm:db_resolver:write")
+	require.True(t, ok)
+}
+
+func TestData_AfterCommit(t *testing.T) {
+	data := &Data{db: testSuite.DB()}
+	tx := NewTransaction(data)
+	ctx := context.Background()
+	var ran []string
+	hook := func(name string) func(context.Context) {
+		return func(ctx context.Context) {
+			_, inTx := ctx.Value(txKey{}).(*gorm.DB)
+			require.False(t, inTx)
+			ran = append(ran, name)
+		}
+	}
+
+	data.AfterCommit(ctx, hook("no tx"))
+	require.Equal(t, []string{"no tx"}, ran)
+
+	require.NoError(t, tx.InTx(ctx, func(ctx context.Context) error {
+		data.AfterCommit(ctx, hook("commit"))
+		require.Equal(t, []string{"no tx"}, ran)
+		return nil
+	}))
+	require.Equal(t, []string{"no tx", "commit"}, ran)
+
+	errBoom := errors.New("boom")
+	require.ErrorIs(t, tx.InTx(ctx, func(ctx context.Context) error {
+		data.AfterCommit(ctx, hook("rollback"))
+		return errBoom
+	}), errBoom)
+	require.Equal(t, []string{"no tx", "commit"}, ran)
+}
+
+func TestData_AfterCommit_Nested(t *testing.T) {
+	data := &Data{db: testSuite.DB()}
+	tx := NewTransaction(data)
+	var ran []string
+	errInner := errors.New("inner")
+
+	require.NoError(t, tx.InTx(context.Background(), func(ctx context.Context) error {
+		require.ErrorIs(t, tx.InTx(ctx, func(ctx context.Context) error {
+			data.AfterCommit(ctx, func(context.Context) { ran = append(ran, "rolled back") })
+			return errInner
+		}), errInner)
+		require.NoError(t, tx.InTx(ctx, func(ctx context.Context) error {
+			data.AfterCommit(ctx, func(context.Context) { ran = append(ran, "inner") })
+			return nil
+		}))
+		// Nested hooks wait for the outer commit.
+		require.Empty(t, ran)
+		return nil
+	}))
+	require.Equal(t, []string{"inner"}, ran)
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport/http"
+
+	"github.com/go-kratos/kratos-layout/pkg/middleware/requestid"
+)
+
+// errorEnvelope is the body of an error when the error envelope is enabled.
+type errorEnvelope struct {
+	Code      int32             `json:"code"`
+	Reason    string            `json:"reason"`
+	Message   string            `json:"message"`
+	Metadata  map[string]string `json:"metadata"`
+	RequestID string            `json:"request_id"`
+}
+
+// dataEnvelope is the body of a successful response when the data envelope
+// is enabled. Data holds the response encoded by the request's codec.
+type dataEnvelope struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// ErrorEncoder returns the HTTP error encoder. With envelope, JSON errors
+// are written as an errorEnvelope carrying the request ID; other codecs,
+// which cannot marshal the envelope, and requests without envelope use
+// kratos' default encoding of the error status.
+func ErrorEncoder(envelope bool) http.EncodeErrorFunc {
+	if !envelope {
+		return http.DefaultErrorEncoder
+	}
+	return func(w nethttp.ResponseWriter, r *nethttp.Request, err error) {
+		codec, _ := http.CodecForRequest(r, "Accept")
+		if codec.Name() != "json" {
+			http.DefaultErrorEncoder(w, r, err)
+			return
+		}
+		se := errors.FromError(err)
+		body := errorEnvelope{
+			Code:      se.Code,
+			Reason:    se.Reason,
+			Message:   se.Message,
+			Metadata:  se.Metadata,
+			RequestID: requestID(w, r),
+		}
+		if body.Metadata == nil {
+			body.Metadata = map[string]string{}
+		}
+		data, err := codec.Marshal(body)
+		if err != nil {
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType(codec.Name()))
+		w.WriteHeader(int(se.Code))
+		_, _ = w.Write(data)
+	}
+}
+
+// ResponseEncoder returns the HTTP response encoder. With envelope, JSON
+// responses are wrapped in a dataEnvelope; other codecs and redirects are
+// encoded like kratos' default encoder does.
+func ResponseEncoder(envelope bool) http.EncodeResponseFunc {
+	if !envelope {
+		return http.DefaultResponseEncoder
+	}
+	return func(w nethttp.ResponseWriter, r *nethttp.Request, v any) error {
+		if _, ok := v.(http.Redirector); ok || v == nil {
+			return http.DefaultResponseEncoder(w, r, v)
+		}
+		codec, _ := http.CodecForRequest(r, "Accept")
+		if codec.Name() != "json" {
+			return http.DefaultResponseEncoder(w, r, v)
+		}
+		data, err := codec.Marshal(v)
+		if err != nil {
+			return err
+		}
+		data, err = codec.Marshal(dataEnvelope{Data: data})
+		if err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", contentType(codec.Name()))
+		_, err = w.Write(data)
+		return err
+	}
+}
+
+// requestID returns the request ID the requestid middleware echoed in the
+// reply header, or the incoming one when the request failed before it ran.
+func requestID(w nethttp.ResponseWriter, r *nethttp.Request) string {
+	if id := w.Header().Get(requestid.HeaderKey); id != "" {
+		return id
+	}
+	return r.Header.Get(requestid.HeaderKey)
+}
+
+func contentType(subtype string) string {
+	return "application/" + subtype
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	_ "github.com/go-kratos/kratos/v2/encoding/proto" // registers the protobuf codec
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/go-kratos/kratos-layout/pkg/middleware/requestid"
+)
+
+func TestErrorEncoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		envelope bool
+		err      error
+		want     string
+		wantCode int
+	}{
+		{
+			name:     "envelope",
+			envelope: true,
+			err:      kerrors.NotFound("GREETER_NOT_FOUND", "greeter not found").WithMetadata(map[string]string{"id": "1"}),
+			want:     `{"code":404,"reason":"GREETER_NOT_FOUND","message":"greeter not found","metadata":{"id":"1"},"request_id":"req-1"}`,
+			wantCode: nethttp.StatusNotFound,
+		},
+		{
+			name:     "envelope of an unknown error",
+			envelope: true,
+			err:      errors.New("boom"),
+			want:     `{"code":500,"reason":"","message":"boom","metadata":{},"request_id":"req-1"}`,
+			wantCode: nethttp.StatusInternalServerError,
+		},
+		{
+			name:     "default",
+			err:      kerrors.BadRequest("INVALID_ARGUMENT", "bad"),
+			want:     `{"code":400,"reason":"INVALID_ARGUMENT","message":"bad","metadata":{}}`,
+			wantCode: nethttp.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(nethttp.MethodGet, "/v1/greeters/1", nil)
+			r.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			w.Header().Set(requestid.HeaderKey, "req-1")
+
+			ErrorEncoder(tt.envelope)(w, r, tt.err)
+			require.Equal(t, tt.wantCode, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			require.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestErrorEncoder_IncomingRequestID(t *testing.T) {
+	r := httptest.NewRequest(nethttp.MethodPost, "/v1/greeters", nil)
+	r.Header.Set(requestid.HeaderKey, "from-client")
+	w := httptest.NewRecorder()
+
+	ErrorEncoder(true)(w, r, kerrors.BadRequest("CODEC", "bad body"))
+	require.JSONEq(t, `{"code":400,"reason":"CODEC","message":"bad body","metadata":{},"request_id":"from-client"}`,
+		w.Body.String())
+}
+
+func TestErrorEncoder_EnvelopeSkipsOtherCodecs(t *testing.T) {
+	r := httptest.NewRequest(nethttp.MethodGet, "/v1/greeters/1", nil)
+	r.Header.Set("Accept", "application/proto")
+	w := httptest.NewRecorder()
+	w.Header().Set(requestid.HeaderKey, "req-1")
+
+	ErrorEncoder(true)(w, r, kerrors.NotFound("GREETER_NOT_FOUND", "greeter not found"))
+	require.Equal(t, nethttp.StatusNotFound, w.Code)
+	require.Equal(t, "application/proto", w.Header().Get("Content-Type"))
+
+	var st kerrors.Status
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &st))
+	require.Equal(t, int32(404), st.Code)
+	require.Equal(t, "GREETER_NOT_FOUND", st.Reason)
+	require.Equal(t, "greeter not found", st.Message)
+}
+
+func TestResponseEncoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		envelope bool
+		accept   string
+		want     string
+	}{
+		{name: "envelope", envelope: true, accept: "application/json", want: `{"data":"kratos"}`},
+		{name: "default", accept: "application/json", want: `"kratos"`},
+		{name: "envelope skips other codecs", envelope: true, accept: "application/proto", want: "\n\x06kratos"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(nethttp.MethodGet, "/v1/greeters/1", nil)
+			r.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+
+			require.NoError(t, ResponseEncoder(tt.envelope)(w, r, wrapperspb.String("kratos")))
+			require.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/service"
+	"github.com/go-kratos/kratos-layout/pkg/event"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/idempotency"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// NewEventServer new an event server, consuming the messages of sub.
+func NewEventServer(c *conf.Server, sub event.Subscriber, idem idempotency.Store, greeterEvents *service.GreeterEventService, logger log.Logger) *event.Server {
+	var opts = []event.Option{
+		event.WithMiddleware(middlewares(c, idem, logger)...),
+		event.WithLogger(logger),
+	}
+	if n := c.GetEvent().GetMaxAttempts(); n > 0 {
+		opts = append(opts, event.WithMaxAttempts(int(n)))
+	}
+	srv := event.NewServer(sub, opts...)
+	event.Subscribe(srv, greeterEvents.GreeterCreated)
+	return srv
+}
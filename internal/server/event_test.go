@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/service"
+	"github.com/go-kratos/kratos-layout/pkg/event"
+)
+
+func TestNewEventServer(t *testing.T) {
+	broker := event.NewMemoryBroker()
+	srv := NewEventServer(&conf.Server{}, broker, nil, service.NewGreeterEventService(log.DefaultLogger), log.DefaultLogger)
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Start(context.Background()) }()
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop(context.Background()))
+		require.NoError(t, <-errc)
+	})
+
+	const topic = "helloworld.v1.GreeterCreated"
+	payload, err := proto.Marshal(&v1.GreeterCreated{Id: 1, Hello: "kratos"})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, broker.Publish(ctx, &event.Message{ID: "1", Topic: topic, Payload: payload}))
+	require.NoError(t, broker.Publish(ctx, &event.Message{ID: "2", Topic: topic, Payload: []byte{0xff}}))
+
+	// Only the malformed event is dead-lettered.
+	require.Eventually(t, func() bool {
+		return len(broker.Messages(event.DeadLetterTopic(topic))) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	dead := broker.Messages(event.DeadLetterTopic(topic))
+	require.Len(t, dead, 1)
+	require.Equal(t, "2", dead[0].ID)
+}
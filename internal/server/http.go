@@ -5,18 +5,18 @@ import (
 	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
 	"github.com/go-kratos/kratos-layout/internal/conf"
 	"github.com/go-kratos/kratos-layout/internal/service"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/idempotency"
 
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, healthSvc *service.HealthService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, idem idempotency.Store, greeter *service.GreeterService, healthSvc *service.HealthService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
-		http.Middleware(
-			recovery.Recovery(),
-		),
+		http.Middleware(middlewares(c, idem, logger)...),
+		http.ErrorEncoder(ErrorEncoder(c.Http.ErrorEnvelope)),
+		http.ResponseEncoder(ResponseEncoder(c.Http.DataEnvelope)),
 	}
 	if c.Http.Network != "" {
 		opts = append(opts, http.Network(c.Http.Network))
@@ -1,8 +1,72 @@
 package server
 
 import (
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/google/wire"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/accesslog"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/idempotency"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/requestid"
+	"github.com/go-kratos/kratos-layout/pkg/middleware/validate"
+	"github.com/go-kratos/kratos-layout/pkg/principal"
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer)
+var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer, NewEventServer)
+
+// middlewares returns the middleware chain of the gRPC, HTTP and event servers.
+func middlewares(c *conf.Server, idem idempotency.Store, logger log.Logger) []middleware.Middleware {
+	return []middleware.Middleware{
+		recovery.Recovery(),
+		tracing.Server(),
+		requestid.Server(),
+		principalMiddleware(c.Principal),
+		accessLogMiddleware(c.AccessLog, logger),
+		validate.Server(validate.WithReason(v1.ErrorReason_INVALID_ARGUMENT.String())),
+		idempotencyMiddleware(c.Idempotency, idem, logger),
+	}
+}
+
+// principalMiddleware returns the middleware storing the caller of a request
+// in its context, taken from the configured trusted header.
+func principalMiddleware(c *conf.Server_Principal) middleware.Middleware {
+	var opts []principal.Option
+	if h := c.GetHeader(); h != "" {
+		opts = append(opts, principal.WithHeader(h))
+	}
+	return principal.Server(opts...)
+}
+
+// accessLogMiddleware returns the access log middleware with the configured
+// dump size.
+func accessLogMiddleware(c *conf.Server_AccessLog, logger log.Logger) middleware.Middleware {
+	var opts []accesslog.Option
+	if size := c.GetMaxDumpSize(); size > 0 {
+		opts = append(opts, accesslog.WithMaxDumpSize(int(size)))
+	}
+	return accesslog.Server(logger, opts...)
+}
+
+// idempotencyMiddleware returns the idempotency middleware of the configured
+// operations. Without a store or operations it passes requests through.
+func idempotencyMiddleware(c *conf.Server_Idempotency, store idempotency.Store, logger log.Logger) middleware.Middleware {
+	if store == nil || len(c.GetOperations()) == 0 {
+		return func(handler middleware.Handler) middleware.Handler { return handler }
+	}
+	opts := []idempotency.Option{
+		idempotency.WithOperations(c.Operations...),
+		idempotency.WithLogger(logger),
+	}
+	if c.Ttl != nil {
+		opts = append(opts, idempotency.WithTTL(c.Ttl.AsDuration()))
+	}
+	if c.LockTtl != nil {
+		opts = append(opts, idempotency.WithLockTTL(c.LockTtl.AsDuration()))
+	}
+	return idempotency.Server(store, opts...)
+}
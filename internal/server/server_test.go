@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/internal/conf"
+	"github.com/go-kratos/kratos-layout/internal/service"
+	"github.com/go-kratos/kratos-layout/pkg/principal"
+)
+
+// principalRepo records the principal of the contexts Delete is called with.
+// Its other methods are not implemented.
+type principalRepo struct {
+	biz.GreeterRepo
+	deletedBy chan string
+}
+
+func (r *principalRepo) Delete(ctx context.Context, _ int64) error {
+	id, _ := principal.FromContext(ctx)
+	r.deletedBy <- id
+	return nil
+}
+
+func TestNewHTTPServer_Principal(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "trusted header", header: "X-Principal-ID", want: "alice"},
+		{name: "untrusted header", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &principalRepo{deletedBy: make(chan string, 1)}
+			uc := biz.NewGreeterUsecase(repo, nil, nil, log.DefaultLogger)
+			c := &conf.Server{Http: &conf.Server_HTTP{}, Principal: &conf.Server_Principal{Header: tt.header}}
+			srv := NewHTTPServer(c, nil, service.NewGreeterService(uc), service.NewHealthService(), log.DefaultLogger)
+
+			r := httptest.NewRequest(nethttp.MethodDelete, "/v1/greeters/1", nil)
+			r.Header.Set("X-Principal-ID", "alice")
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, r)
+			require.Equal(t, nethttp.StatusOK, w.Code, w.Body.String())
+			require.Equal(t, tt.want, <-repo.deletedBy)
+		})
+	}
+}
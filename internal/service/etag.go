@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/transport"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+)
+
+// HTTP headers carrying entity tags.
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+// formatETag returns the strong entity tag of a resource version.
+func formatETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseETag returns the resource version an etag field or If-Match header
+// requires, or 0 when it is empty or "*", which match any version. A header
+// may list several tags. Weak tags never match, since If-Match compares tags
+// strongly (RFC 9110, section 13.1.1), so a list of weak tags only fails with
+// VERSION_MISMATCH. A list of several strong tags is not supported.
+func parseETag(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	var versions []int64
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		weak := strings.HasPrefix(tag, "W/")
+		version, ok := tagVersion(strings.TrimPrefix(tag, "W/"))
+		if !ok {
+			return 0, v1.ErrorInvalidArgument("invalid etag %q", tag)
+		}
+		if !weak {
+			versions = append(versions, version)
+		}
+	}
+	switch len(versions) {
+	case 0:
+		return 0, v1.ErrorVersionMismatch("weak etag %s does not match", value)
+	case 1:
+		return versions[0], nil
+	default:
+		return 0, v1.ErrorInvalidArgument("several etags %s are not supported", value)
+	}
+}
+
+// tagVersion returns the version of an entity tag without its weak
+// indicator. The quotes may be omitted.
+func tagVersion(tag string) (int64, bool) {
+	if len(tag) >= 2 && strings.HasPrefix(tag, `"`) && strings.HasSuffix(tag, `"`) {
+		tag = tag[1 : len(tag)-1]
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	return version, err == nil && version > 0
+}
+
+// requestETag returns the etag of the request: the etag field, or the
+// If-Match header when the field is empty. Both must agree when both are set.
+func requestETag(ctx context.Context, etag string) (string, error) {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return etag, nil
+	}
+	ifMatch := strings.Join(tr.RequestHeader().Values(headerIfMatch), ",")
+	switch {
+	case ifMatch == "":
+		return etag, nil
+	case etag == "":
+		return ifMatch, nil
+	case strings.TrimSpace(ifMatch) != strings.TrimSpace(etag):
+		return "", v1.ErrorInvalidArgument("etag %s conflicts with If-Match %s", etag, ifMatch)
+	default:
+		return etag, nil
+	}
+}
+
+// setETag sets the ETag reply header to the etag of a resource version.
+func setETag(ctx context.Context, version int64) {
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		tr.ReplyHeader().Set(headerETag, formatETag(version))
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+)
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		version int64
+		reason  v1.ErrorReason
+		code    int
+	}{
+		{name: "empty"},
+		{name: "any", value: "*"},
+		{name: "quoted", value: `"3"`, version: 3},
+		{name: "bare", value: "3", version: 3},
+		{name: "spaces", value: ` "3" `, version: 3},
+		{name: "strong among weak", value: `W/"2", "3"`, version: 3},
+		{name: "weak", value: `W/"3"`, reason: v1.ErrorReason_VERSION_MISMATCH, code: 412},
+		{name: "several weak", value: `W/"2", W/"3"`, reason: v1.ErrorReason_VERSION_MISMATCH, code: 412},
+		{name: "several strong", value: `"2", "3"`, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "garbage", value: "abc", reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "unterminated", value: `"3`, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "weak garbage", value: `W/abc`, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "zero", value: `"0"`, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "negative", value: `"-1"`, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "empty element", value: `"3",`, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := parseETag(tt.value)
+			if tt.code != 0 {
+				requireReason(t, err, tt.reason, tt.code)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestGreeterService_UpdateGreeter_IfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch []string
+		etag    string
+		reason  v1.ErrorReason
+		code    int
+	}{
+		{name: "quoted", ifMatch: []string{`"1"`}},
+		{name: "any", ifMatch: []string{"*"}},
+		{name: "body only", etag: `"1"`},
+		{name: "header and body agree", ifMatch: []string{`"1"`}, etag: `"1"`},
+		{name: "multi-value header", ifMatch: []string{`W/"1"`, `"1"`}},
+		{name: "stale", ifMatch: []string{`"2"`}, reason: v1.ErrorReason_VERSION_MISMATCH, code: 412},
+		{name: "weak", ifMatch: []string{`W/"1"`}, reason: v1.ErrorReason_VERSION_MISMATCH, code: 412},
+		{name: "several strong", ifMatch: []string{`"1"`, `"2"`}, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "garbage", ifMatch: []string{"abc"}, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+		{name: "header and body conflict", ifMatch: []string{`"1"`}, etag: `"2"`, reason: v1.ErrorReason_INVALID_ARGUMENT, code: 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestGreeterService(t)
+			createCtx, _ := serverContext(nil)
+			created, err := s.CreateGreeter(createCtx, &v1.CreateGreeterRequest{Hello: "kratos"})
+			require.NoError(t, err)
+
+			header := http.Header{}
+			for _, v := range tt.ifMatch {
+				header.Add("If-Match", v)
+			}
+			ctx, tr := serverContext(header)
+			updated, err := s.UpdateGreeter(ctx, &v1.UpdateGreeterRequest{Id: created.Id, Hello: "world", Etag: tt.etag})
+			if tt.code != 0 {
+				requireReason(t, err, tt.reason, tt.code)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, `"2"`, updated.Etag)
+			require.Equal(t, `"2"`, tr.ReplyHeader().Get("ETag"))
+		})
+	}
+}
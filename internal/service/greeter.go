@@ -3,10 +3,25 @@ package service
 import (
 	"context"
 
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
 	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/query"
 )
 
+// greeterListSchema lists the fields ListGreeters can filter and order by.
+var greeterListSchema = query.Schema{
+	Fields: map[string]query.Kind{
+		"id":          query.Int,
+		"hello":       query.String,
+		"create_time": query.Time,
+		"update_time": query.Time,
+	},
+	Key: "id",
+}
+
 // GreeterService is a greeter service.
 type GreeterService struct {
 	v1.UnimplementedGreeterServer
@@ -27,3 +42,113 @@ func (s *GreeterService) SayHello(ctx context.Context, in *v1.HelloRequest) (*v1
 	}
 	return &v1.HelloReply{Message: "Hello " + g.Hello}, nil
 }
+
+// CreateGreeter implements helloworld.GreeterServer.
+func (s *GreeterService) CreateGreeter(ctx context.Context, in *v1.CreateGreeterRequest) (*v1.GreeterInfo, error) {
+	g, err := s.uc.CreateGreeter(ctx, &biz.Greeter{Hello: in.Hello})
+	if err != nil {
+		return nil, err
+	}
+	setETag(ctx, g.Version)
+	return greeterToProto(g), nil
+}
+
+// GetGreeter implements helloworld.GreeterServer.
+func (s *GreeterService) GetGreeter(ctx context.Context, in *v1.GetGreeterRequest) (*v1.GreeterInfo, error) {
+	g, err := s.uc.GetGreeter(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	setETag(ctx, g.Version)
+	return greeterToProto(g), nil
+}
+
+// UpdateGreeter implements helloworld.GreeterServer. The update is
+// conditional on the etag of the request or its If-Match header.
+func (s *GreeterService) UpdateGreeter(ctx context.Context, in *v1.UpdateGreeterRequest) (*v1.GreeterInfo, error) {
+	etag, err := requestETag(ctx, in.Etag)
+	if err != nil {
+		return nil, err
+	}
+	version, err := parseETag(etag)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.uc.UpdateGreeter(ctx, &biz.Greeter{ID: in.Id, Hello: in.Hello, Version: version})
+	if err != nil {
+		return nil, err
+	}
+	setETag(ctx, g.Version)
+	return greeterToProto(g), nil
+}
+
+// DeleteGreeter implements helloworld.GreeterServer.
+func (s *GreeterService) DeleteGreeter(ctx context.Context, in *v1.DeleteGreeterRequest) (*emptypb.Empty, error) {
+	if err := s.uc.DeleteGreeter(ctx, in.Id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreGreeter implements helloworld.GreeterServer.
+func (s *GreeterService) RestoreGreeter(ctx context.Context, in *v1.RestoreGreeterRequest) (*v1.GreeterInfo, error) {
+	g, err := s.uc.RestoreGreeter(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	setETag(ctx, g.Version)
+	return greeterToProto(g), nil
+}
+
+// ListGreeters implements helloworld.GreeterServer.
+func (s *GreeterService) ListGreeters(ctx context.Context, in *v1.ListGreetersRequest) (*v1.ListGreetersResponse, error) {
+	q, err := query.Parse(greeterListSchema, query.Request{
+		PageSize:  in.PageSize,
+		PageToken: in.PageToken,
+		Filter:    in.Filter,
+		OrderBy:   in.OrderBy,
+	})
+	if err != nil {
+		return nil, v1.ErrorInvalidArgument("%s", err.Error())
+	}
+	page, err := s.uc.ListGreeters(ctx, &biz.ListQuery{
+		Filter:   q.Filter,
+		OrderBy:  q.OrderBy,
+		PageSize: q.PageSize,
+		After:    q.After,
+
+		IncludeDeleted: in.IncludeDeleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	next, err := q.NextPageToken(page.Next)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &v1.ListGreetersResponse{
+		Greeters:      make([]*v1.GreeterInfo, 0, len(page.Items)),
+		NextPageToken: next,
+	}
+	for _, g := range page.Items {
+		out.Greeters = append(out.Greeters, greeterToProto(g))
+	}
+	return out, nil
+}
+
+func greeterToProto(g *biz.Greeter) *v1.GreeterInfo {
+	out := &v1.GreeterInfo{
+		Id:         g.ID,
+		Hello:      g.Hello,
+		CreateTime: timestamppb.New(g.CreatedAt),
+		UpdateTime: timestamppb.New(g.UpdatedAt),
+		Etag:       formatETag(g.Version),
+		CreatedBy:  g.CreatedBy,
+		UpdatedBy:  g.UpdatedBy,
+	}
+	if !g.DeletedAt.IsZero() {
+		out.DeleteTime = timestamppb.New(g.DeletedAt)
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+)
+
+// GreeterEventService handles greeter events received by the event server.
+type GreeterEventService struct {
+	log *log.Helper
+}
+
+// NewGreeterEventService new a greeter event service.
+func NewGreeterEventService(logger log.Logger) *GreeterEventService {
+	return &GreeterEventService{log: log.NewHelper(logger)}
+}
+
+// GreeterCreated handles v1.GreeterCreated. Returning an error has the
+// event delivered again.
+func (s *GreeterEventService) GreeterCreated(ctx context.Context, e *v1.GreeterCreated) error {
+	s.log.WithContext(ctx).Infof("greeter %d created by %q: %s", e.Id, e.CreatedBy, e.Hello)
+	return nil
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/go-kratos/kratos-layout/api/helloworld/v1"
+	"github.com/go-kratos/kratos-layout/internal/biz"
+	"github.com/go-kratos/kratos-layout/pkg/query"
+)
+
+// fakeGreeterRepo is an in-memory biz.GreeterRepo. List returns page and
+// records the query it was called with.
+type fakeGreeterRepo struct {
+	mu       sync.Mutex
+	greeters map[int64]*biz.Greeter
+	nextID   int64
+
+	page      *biz.Page[*biz.Greeter]
+	lastQuery *biz.ListQuery
+}
+
+func newFakeGreeterRepo() *fakeGreeterRepo {
+	return &fakeGreeterRepo{greeters: make(map[int64]*biz.Greeter)}
+}
+
+func (r *fakeGreeterRepo) Save(_ context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	saved := *g
+	saved.ID, saved.Version = r.nextID, 1
+	saved.CreatedAt, saved.UpdatedAt = time.Now(), time.Now()
+	r.greeters[saved.ID] = &saved
+	return &saved, nil
+}
+
+func (r *fakeGreeterRepo) Update(_ context.Context, g *biz.Greeter) (*biz.Greeter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.greeters[g.ID]
+	if !ok || !cur.DeletedAt.IsZero() {
+		return nil, biz.ErrGreeterNotFound
+	}
+	if g.Version != 0 && g.Version != cur.Version {
+		return nil, biz.ErrVersionMismatch
+	}
+	cur.Hello = g.Hello
+	cur.Version++
+	updated := *cur
+	return &updated, nil
+}
+
+func (r *fakeGreeterRepo) FindByID(_ context.Context, id int64) (*biz.Greeter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.greeters[id]
+	if !ok || !g.DeletedAt.IsZero() {
+		return nil, biz.ErrGreeterNotFound
+	}
+	found := *g
+	return &found, nil
+}
+
+func (r *fakeGreeterRepo) ListByHello(context.Context, string) ([]*biz.Greeter, error) {
+	panic("not implemented")
+}
+
+func (r *fakeGreeterRepo) ListAll(context.Context) ([]*biz.Greeter, error) {
+	panic("not implemented")
+}
+
+func (r *fakeGreeterRepo) List(_ context.Context, q *biz.ListQuery) (*biz.Page[*biz.Greeter], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastQuery = q
+	return r.page, nil
+}
+
+func (r *fakeGreeterRepo) Delete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.greeters[id]
+	if !ok || !g.DeletedAt.IsZero() {
+		return biz.ErrGreeterNotFound
+	}
+	g.DeletedAt = time.Now()
+	g.Version++
+	return nil
+}
+
+func (r *fakeGreeterRepo) Restore(_ context.Context, id int64) (*biz.Greeter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.greeters[id]
+	if !ok {
+		return nil, biz.ErrGreeterNotFound
+	}
+	if !g.DeletedAt.IsZero() {
+		g.DeletedAt = time.Time{}
+		g.Version++
+	}
+	restored := *g
+	return &restored, nil
+}
+
+type fakeTransaction struct{}
+
+func (fakeTransaction) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeEventPublisher struct {
+	events []*biz.Event
+}
+
+func (p *fakeEventPublisher) Publish(_ context.Context, events ...*biz.Event) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func newTestGreeterService(t *testing.T) (*GreeterService, *fakeGreeterRepo, *fakeEventPublisher) {
+	t.Helper()
+	repo := newFakeGreeterRepo()
+	events := &fakeEventPublisher{}
+	uc := biz.NewGreeterUsecase(repo, fakeTransaction{}, events, log.DefaultLogger)
+	return NewGreeterService(uc), repo, events
+}
+
+// testTransport is a server transport.Transporter with the given request header.
+type testTransport struct {
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return "" }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.reqHeader }
+func (tr *testTransport) ReplyHeader() transport.Header   { return tr.replyHeader }
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string        { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Values(key string) []string   { return http.Header(hc).Values(key) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// serverContext returns a server context whose request carries header.
+func serverContext(header http.Header) (context.Context, *testTransport) {
+	if header == nil {
+		header = http.Header{}
+	}
+	tr := &testTransport{reqHeader: headerCarrier(header), replyHeader: headerCarrier{}}
+	return transport.NewServerContext(context.Background(), tr), tr
+}
+
+// requireReason requires err to be a kratos error with reason and code.
+func requireReason(t *testing.T, err error, reason v1.ErrorReason, code int) {
+	t.Helper()
+	require.Error(t, err)
+	se := errors.FromError(err)
+	require.Equal(t, reason.String(), se.Reason)
+	require.Equal(t, int32(code), se.Code)
+}
+
+func TestGreeterService_CRUD(t *testing.T) {
+	s, _, events := newTestGreeterService(t)
+
+	ctx, tr := serverContext(nil)
+	created, err := s.CreateGreeter(ctx, &v1.CreateGreeterRequest{Hello: "kratos"})
+	require.NoError(t, err)
+	require.Equal(t, "kratos", created.Hello)
+	require.Equal(t, `"1"`, created.Etag)
+	require.Equal(t, `"1"`, tr.ReplyHeader().Get("ETag"))
+	require.NotNil(t, created.CreateTime)
+	require.Nil(t, created.DeleteTime)
+	require.Len(t, events.events, 1)
+	require.Equal(t, created.Id, events.events[0].Payload.(*v1.GreeterCreated).Id)
+
+	ctx, tr = serverContext(nil)
+	got, err := s.GetGreeter(ctx, &v1.GetGreeterRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, created.Id, got.Id)
+	require.Equal(t, `"1"`, tr.ReplyHeader().Get("ETag"))
+
+	ctx, tr = serverContext(nil)
+	updated, err := s.UpdateGreeter(ctx, &v1.UpdateGreeterRequest{Id: created.Id, Hello: "world", Etag: created.Etag})
+	require.NoError(t, err)
+	require.Equal(t, "world", updated.Hello)
+	require.Equal(t, `"2"`, updated.Etag)
+	require.Equal(t, `"2"`, tr.ReplyHeader().Get("ETag"))
+
+	_, err = s.DeleteGreeter(context.Background(), &v1.DeleteGreeterRequest{Id: created.Id})
+	require.NoError(t, err)
+	_, err = s.GetGreeter(context.Background(), &v1.GetGreeterRequest{Id: created.Id})
+	requireReason(t, err, v1.ErrorReason_GREETER_NOT_FOUND, 404)
+
+	ctx, tr = serverContext(nil)
+	restored, err := s.RestoreGreeter(ctx, &v1.RestoreGreeterRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "world", restored.Hello)
+	require.Equal(t, `"4"`, restored.Etag)
+	require.Equal(t, `"4"`, tr.ReplyHeader().Get("ETag"))
+}
+
+func TestGreeterService_SayHello(t *testing.T) {
+	s, _, _ := newTestGreeterService(t)
+
+	reply, err := s.SayHello(context.Background(), &v1.HelloRequest{Name: "kratos"})
+	require.NoError(t, err)
+	require.Equal(t, "Hello kratos", reply.Message)
+}
+
+func TestGreeterService_Errors(t *testing.T) {
+	s, _, _ := newTestGreeterService(t)
+	ctx := context.Background()
+
+	_, err := s.CreateGreeter(ctx, &v1.CreateGreeterRequest{})
+	requireReason(t, err, v1.ErrorReason_INVALID_ARGUMENT, 400)
+
+	_, err = s.GetGreeter(ctx, &v1.GetGreeterRequest{Id: 1})
+	requireReason(t, err, v1.ErrorReason_GREETER_NOT_FOUND, 404)
+	_, err = s.UpdateGreeter(ctx, &v1.UpdateGreeterRequest{Id: 1, Hello: "a"})
+	requireReason(t, err, v1.ErrorReason_GREETER_NOT_FOUND, 404)
+	_, err = s.DeleteGreeter(ctx, &v1.DeleteGreeterRequest{Id: 1})
+	requireReason(t, err, v1.ErrorReason_GREETER_NOT_FOUND, 404)
+	_, err = s.RestoreGreeter(ctx, &v1.RestoreGreeterRequest{Id: 1})
+	requireReason(t, err, v1.ErrorReason_GREETER_NOT_FOUND, 404)
+
+	created, err := s.CreateGreeter(ctx, &v1.CreateGreeterRequest{Hello: "a"})
+	require.NoError(t, err)
+	_, err = s.UpdateGreeter(ctx, &v1.UpdateGreeterRequest{Id: created.Id})
+	requireReason(t, err, v1.ErrorReason_INVALID_ARGUMENT, 400)
+	_, err = s.UpdateGreeter(ctx, &v1.UpdateGreeterRequest{Id: created.Id, Hello: "b", Etag: `"7"`})
+	requireReason(t, err, v1.ErrorReason_VERSION_MISMATCH, 412)
+}
+
+func TestGreeterService_ListGreeters(t *testing.T) {
+	s, repo, _ := newTestGreeterService(t)
+	ctx := context.Background()
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo.page = &biz.Page[*biz.Greeter]{
+		Items: []*biz.Greeter{
+			{ID: 1, Hello: "a", Version: 1, CreatedAt: created},
+			{ID: 2, Hello: "a", Version: 3, CreatedAt: created, DeletedAt: created},
+		},
+		Next: []any{created, int64(2)},
+	}
+
+	resp, err := s.ListGreeters(ctx, &v1.ListGreetersRequest{
+		PageSize:       2,
+		Filter:         `hello = "a"`,
+		OrderBy:        "create_time desc",
+		IncludeDeleted: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Greeters, 2)
+	require.Equal(t, `"3"`, resp.Greeters[1].Etag)
+	require.NotNil(t, resp.Greeters[1].DeleteTime)
+	require.NotEmpty(t, resp.NextPageToken)
+
+	q := repo.lastQuery
+	require.Equal(t, query.Compare{Field: "hello", Op: query.OpEq, Value: "a"}, q.Filter)
+	require.Equal(t, []query.OrderField{{Field: "create_time", Desc: true}, {Field: "id"}}, q.OrderBy)
+	require.Equal(t, 2, q.PageSize)
+	require.Nil(t, q.After)
+	require.True(t, q.IncludeDeleted)
+
+	// The page token carries the values of the last greeter to the next page.
+	repo.page = &biz.Page[*biz.Greeter]{}
+	resp, err = s.ListGreeters(ctx, &v1.ListGreetersRequest{
+		PageSize:  2,
+		Filter:    `hello = "a"`,
+		OrderBy:   "create_time desc",
+		PageToken: resp.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Greeters)
+	require.Empty(t, resp.NextPageToken)
+	require.Len(t, repo.lastQuery.After, 2)
+	require.True(t, created.Equal(repo.lastQuery.After[0].(time.Time)))
+	require.Equal(t, int64(2), repo.lastQuery.After[1])
+	require.False(t, repo.lastQuery.IncludeDeleted)
+}
+
+func TestGreeterService_ListGreeters_InvalidArgument(t *testing.T) {
+	s, repo, _ := newTestGreeterService(t)
+	ctx := context.Background()
+	repo.page = &biz.Page[*biz.Greeter]{Next: []any{int64(1)}}
+	first, err := s.ListGreeters(ctx, &v1.ListGreetersRequest{})
+	require.NoError(t, err)
+
+	for _, in := range []*v1.ListGreetersRequest{
+		{Filter: `unknown = "a"`},
+		{Filter: `id = "not a number"`},
+		{OrderBy: "hello sideways"},
+		{PageSize: -1},
+		{PageToken: "garbage"},
+		// A page token only continues the list it was returned for.
+		{PageToken: first.NextPageToken, Filter: `hello = "a"`},
+	} {
+		_, err := s.ListGreeters(ctx, in)
+		requireReason(t, err, v1.ErrorReason_INVALID_ARGUMENT, 400)
+	}
+}
@@ -0,0 +1,85 @@
+// Package event defines the messages services exchange through a broker,
+// the interfaces brokers implement, and a transport.Server consuming them.
+package event
+
+import (
+	"context"
+	"strconv"
+)
+
+// Headers set on dead letters.
+const (
+	// HeaderDeadLetterError is the error of the last delivery of a dead letter.
+	HeaderDeadLetterError = "Dead-Letter-Error"
+	// HeaderDeadLetterAttempts is the number of deliveries of a dead letter.
+	HeaderDeadLetterAttempts = "Dead-Letter-Attempts"
+)
+
+// Message is an event on its way through a broker.
+type Message struct {
+	// ID identifies the event. Brokers deliver at least once, so consumers
+	// use it to drop redeliveries.
+	ID string
+	// Topic names the kind of event, e.g. the full name of its payload message.
+	Topic string
+	// Key identifies the aggregate the event is about.
+	Key string
+	// Payload is the encoded event.
+	Payload []byte
+	// Headers are optional metadata of the event.
+	Headers map[string]string
+}
+
+// Publisher publishes messages to a broker.
+type Publisher interface {
+	// Publish sends msg to the subscribers of msg.Topic. Once it returns nil
+	// the broker has accepted msg.
+	Publish(ctx context.Context, msg *Message) error
+}
+
+// Delivery is a message delivered to a consumer.
+type Delivery struct {
+	Message
+	// Attempt counts the deliveries of the message, starting at 1.
+	Attempt int
+	// Receipt identifies the delivery to the Subscriber that returned it.
+	Receipt string
+}
+
+// Subscriber receives messages as a member of a consumer group: every
+// message of a topic is delivered to one member of the group, and delivered
+// again until it is acknowledged.
+type Subscriber interface {
+	// Receive waits for messages of topic and returns them. It returns no
+	// messages when none arrived within its polling interval.
+	Receive(ctx context.Context, topic string) ([]*Delivery, error)
+	// Ack acknowledges d, so it is not delivered again.
+	Ack(ctx context.Context, d *Delivery) error
+	// DeadLetter publishes d to the DeadLetterTopic of its topic, with cause
+	// in its headers, and acknowledges it.
+	DeadLetter(ctx context.Context, d *Delivery, cause error) error
+}
+
+// SubscriberCloser is implemented by Subscribers that release what they
+// hold, such as their consumer in a group, once the Server stopped.
+type SubscriberCloser interface {
+	Close(ctx context.Context) error
+}
+
+// DeadLetterTopic returns the topic the dead letters of topic go to.
+func DeadLetterTopic(topic string) string {
+	return topic + ":dead"
+}
+
+// DeadLetterMessage returns the message Subscriber.DeadLetter publishes for d.
+func DeadLetterMessage(d *Delivery, cause error) *Message {
+	msg := d.Message
+	msg.Topic = DeadLetterTopic(d.Topic)
+	msg.Headers = make(map[string]string, len(d.Headers)+2)
+	for k, v := range d.Headers {
+		msg.Headers[k] = v
+	}
+	msg.Headers[HeaderDeadLetterError] = cause.Error()
+	msg.Headers[HeaderDeadLetterAttempts] = strconv.Itoa(d.Attempt)
+	return &msg
+}
@@ -0,0 +1,112 @@
+package event
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"sync"
+)
+
+// MemoryBroker is a Publisher and Subscriber keeping messages in memory, for
+// tests. Its subscribers form a single consumer group, and messages that
+// are not acknowledged are delivered again on the next Receive.
+type MemoryBroker struct {
+	mu       sync.Mutex
+	messages map[string][]*Message
+	// delivered counts the messages of a topic delivered at least once.
+	delivered map[string]int
+	// pending holds the unacknowledged deliveries of a topic, oldest first.
+	pending map[string][]*Delivery
+	// published is closed and replaced on every publish.
+	published chan struct{}
+}
+
+// NewMemoryBroker creates an empty MemoryBroker.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		messages:  make(map[string][]*Message),
+		delivered: make(map[string]int),
+		pending:   make(map[string][]*Delivery),
+		published: make(chan struct{}),
+	}
+}
+
+// Publish implements Publisher.
+func (b *MemoryBroker) Publish(_ context.Context, msg *Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m := *msg
+	m.Headers = maps.Clone(msg.Headers)
+	b.messages[msg.Topic] = append(b.messages[msg.Topic], &m)
+	close(b.published)
+	b.published = make(chan struct{})
+	return nil
+}
+
+// Messages returns the messages published to topic, oldest first.
+func (b *MemoryBroker) Messages(topic string) []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Message(nil), b.messages[topic]...)
+}
+
+// Receive implements Subscriber. It returns the unacknowledged deliveries
+// of topic again, or else waits for new messages until ctx is done.
+func (b *MemoryBroker) Receive(ctx context.Context, topic string) ([]*Delivery, error) {
+	for {
+		b.mu.Lock()
+		ds := b.receive(topic)
+		published := b.published
+		b.mu.Unlock()
+		if len(ds) > 0 {
+			return ds, nil
+		}
+		select {
+		case <-published:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (b *MemoryBroker) receive(topic string) []*Delivery {
+	pending := b.pending[topic]
+	for _, msg := range b.messages[topic][b.delivered[topic]:] {
+		pending = append(pending, &Delivery{
+			Message: *msg,
+			Receipt: topic + "/" + strconv.Itoa(b.delivered[topic]),
+		})
+		b.delivered[topic]++
+	}
+	b.pending[topic] = pending
+
+	ds := make([]*Delivery, 0, len(pending))
+	for _, d := range pending {
+		d.Attempt++
+		c := *d
+		ds = append(ds, &c)
+	}
+	return ds
+}
+
+// Ack implements Subscriber.
+func (b *MemoryBroker) Ack(_ context.Context, d *Delivery) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pending := b.pending[d.Topic]
+	for i, p := range pending {
+		if p.Receipt == d.Receipt {
+			b.pending[d.Topic] = append(pending[:i:i], pending[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// DeadLetter implements Subscriber.
+func (b *MemoryBroker) DeadLetter(ctx context.Context, d *Delivery, cause error) error {
+	if err := b.Publish(ctx, DeadLetterMessage(d, cause)); err != nil {
+		return err
+	}
+	return b.Ack(ctx, d)
+}
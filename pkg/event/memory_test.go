@@ -0,0 +1,74 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBroker(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx := context.Background()
+
+	msg := &Message{ID: "1", Topic: "t", Key: "a", Payload: []byte("x")}
+	require.NoError(t, b.Publish(ctx, msg))
+	require.NoError(t, b.Publish(ctx, &Message{ID: "2", Topic: "t", Key: "a"}))
+	require.NoError(t, b.Publish(ctx, &Message{ID: "3", Topic: "other"}))
+
+	got := b.Messages("t")
+	require.Len(t, got, 2)
+	require.Equal(t, msg, got[0])
+	require.Equal(t, "2", got[1].ID)
+	require.Empty(t, b.Messages("missing"))
+
+	// Published messages are copies.
+	msg.Key = "changed"
+	require.Equal(t, "a", b.Messages("t")[0].Key)
+}
+
+func TestMemoryBroker_Subscriber(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx := context.Background()
+	require.NoError(t, b.Publish(ctx, &Message{ID: "1", Topic: "t", Headers: map[string]string{"h": "v"}}))
+	require.NoError(t, b.Publish(ctx, &Message{ID: "2", Topic: "t"}))
+
+	ds, err := b.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+	require.Equal(t, "1", ds[0].ID)
+	require.Equal(t, "v", ds[0].Headers["h"])
+	require.Equal(t, 1, ds[0].Attempt)
+
+	// Unacknowledged deliveries are delivered again.
+	require.NoError(t, b.Ack(ctx, ds[0]))
+	ds, err = b.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	require.Equal(t, "2", ds[0].ID)
+	require.Equal(t, 2, ds[0].Attempt)
+
+	require.NoError(t, b.DeadLetter(ctx, ds[0], errors.New("boom")))
+	dead := b.Messages(DeadLetterTopic("t"))
+	require.Len(t, dead, 1)
+	require.Equal(t, "2", dead[0].ID)
+	require.Equal(t, map[string]string{HeaderDeadLetterError: "boom", HeaderDeadLetterAttempts: "2"}, dead[0].Headers)
+
+	// With nothing to deliver, Receive waits for a publish.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = b.Publish(ctx, &Message{ID: "3", Topic: "t"})
+	}()
+	ds, err = b.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	require.Equal(t, "3", ds[0].ID)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.NoError(t, b.Ack(ctx, ds[0]))
+	_, err = b.Receive(cctx, "t")
+	require.ErrorIs(t, err, context.Canceled)
+}
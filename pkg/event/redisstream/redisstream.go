@@ -0,0 +1,154 @@
+// Package redisstream implements the event interfaces on Redis Streams. Each
+// topic is a stream, each message one stream entry, and subscribers are
+// members of a stream consumer group.
+package redisstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/go-kratos/kratos-layout/pkg/event"
+)
+
+const (
+	// DefaultStreamPrefix is prepended to topics to name their streams.
+	DefaultStreamPrefix = "stream:"
+	// DefaultBatchSize is the most messages a Subscriber receives at once.
+	DefaultBatchSize = 10
+	// DefaultBlock is how long a Subscriber waits for new messages.
+	DefaultBlock = time.Second
+	// DefaultRetryDelay is how long a delivered message stays unacknowledged
+	// before it is delivered again.
+	DefaultRetryDelay = 30 * time.Second
+	// DefaultStartID is where a new consumer group starts reading a stream:
+	// at its first entry.
+	DefaultStartID = "0"
+)
+
+// Fields of a stream entry.
+const (
+	fieldID      = "id"
+	fieldKey     = "key"
+	fieldPayload = "payload"
+	fieldHeaders = "headers"
+)
+
+// Option is publisher and subscriber option.
+type Option func(*options)
+
+type options struct {
+	prefix     string
+	maxLen     int64
+	batchSize  int64
+	block      time.Duration
+	retryDelay time.Duration
+	startID    string
+}
+
+// WithStreamPrefix sets the prefix of stream keys, DefaultStreamPrefix by default.
+func WithStreamPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithMaxLen trims streams to about n entries on every publish. Streams are
+// not trimmed by default.
+func WithMaxLen(n int64) Option {
+	return func(o *options) {
+		o.maxLen = n
+	}
+}
+
+// WithBatchSize sets the most messages a Subscriber receives at once,
+// DefaultBatchSize by default.
+func WithBatchSize(n int64) Option {
+	return func(o *options) {
+		o.batchSize = n
+	}
+}
+
+// WithBlock sets how long a Subscriber waits for new messages before
+// Receive returns none, DefaultBlock by default.
+func WithBlock(d time.Duration) Option {
+	return func(o *options) {
+		o.block = d
+	}
+}
+
+// WithRetryDelay sets how long a delivered message stays unacknowledged
+// before a Subscriber delivers it again, DefaultRetryDelay by default. It
+// must exceed the time handlers take, or messages being handled are
+// delivered twice.
+func WithRetryDelay(d time.Duration) Option {
+	return func(o *options) {
+		o.retryDelay = d
+	}
+}
+
+// WithStartID sets the ID of the stream entry after which a Subscriber
+// creating its consumer group starts reading, DefaultStartID by default.
+// "$" skips the entries added before, also those the outbox relay added
+// before the group was first created.
+func WithStartID(id string) Option {
+	return func(o *options) {
+		o.startID = id
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		prefix:     DefaultStreamPrefix,
+		batchSize:  DefaultBatchSize,
+		block:      DefaultBlock,
+		retryDelay: DefaultRetryDelay,
+		startID:    DefaultStartID,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Publisher is an event.Publisher adding messages to Redis Streams.
+type Publisher struct {
+	rdb  redis.UniversalClient
+	opts options
+}
+
+// NewPublisher creates a Publisher on rdb.
+func NewPublisher(rdb redis.UniversalClient, opts ...Option) *Publisher {
+	return &Publisher{rdb: rdb, opts: newOptions(opts)}
+}
+
+// Publish implements event.Publisher.
+func (p *Publisher) Publish(ctx context.Context, msg *event.Message) error {
+	return publish(ctx, p.rdb, p.opts, msg)
+}
+
+func publish(ctx context.Context, rdb redis.UniversalClient, o options, msg *event.Message) error {
+	values := []any{fieldID, msg.ID, fieldKey, msg.Key, fieldPayload, msg.Payload}
+	if len(msg.Headers) > 0 {
+		headers, err := json.Marshal(msg.Headers)
+		if err != nil {
+			return err
+		}
+		values = append(values, fieldHeaders, headers)
+	}
+	args := &redis.XAddArgs{
+		Stream: o.prefix + msg.Topic,
+		Values: values,
+	}
+	if o.maxLen > 0 {
+		args.MaxLen = o.maxLen
+		args.Approx = true
+	}
+	if err := rdb.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("publish %s to %s failed: %w", msg.ID, args.Stream, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package redisstream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/pkg/event"
+)
+
+func TestPublisher(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	ctx := context.Background()
+
+	pub := NewPublisher(rdb, WithMaxLen(100))
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "1", Topic: "t", Key: "a", Payload: []byte{0, 1}}))
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "2", Topic: "t", Key: "b"}))
+
+	entries, err := rdb.XRange(ctx, "stream:t", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, map[string]any{"id": "1", "key": "a", "payload": "\x00\x01"}, entries[0].Values)
+	require.Equal(t, "2", entries[1].Values["id"])
+
+	require.NoError(t, NewPublisher(rdb, WithStreamPrefix("")).Publish(ctx, &event.Message{ID: "3", Topic: "t"}))
+	n, err := rdb.XLen(ctx, "t").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
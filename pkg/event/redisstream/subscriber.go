@@ -0,0 +1,217 @@
+package redisstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/go-kratos/kratos-layout/pkg/event"
+)
+
+// Subscriber is an event.Subscriber reading Redis Streams as a consumer of
+// a consumer group. The group is created WithStartID when it subscribes to
+// the stream first, by default at the start of the stream, so that it also
+// receives the messages published before it existed.
+//
+// Unacknowledged messages stay pending in the group. Once they were pending
+// WithRetryDelay, any consumer of the group claims and delivers them again,
+// which also recovers the messages of consumers that went away. Close
+// removes the consumer from its groups unless it has pending messages.
+type Subscriber struct {
+	rdb      redis.UniversalClient
+	group    string
+	consumer string
+	opts     options
+
+	mu      sync.Mutex
+	streams map[string]bool
+}
+
+// NewSubscriber creates a Subscriber on rdb named consumer in group.
+func NewSubscriber(rdb redis.UniversalClient, group, consumer string, opts ...Option) *Subscriber {
+	return &Subscriber{
+		rdb:      rdb,
+		group:    group,
+		consumer: consumer,
+		opts:     newOptions(opts),
+		streams:  make(map[string]bool),
+	}
+}
+
+// Receive implements event.Subscriber. It returns the messages due for
+// another delivery first, and otherwise waits WithBlock for new ones.
+func (s *Subscriber) Receive(ctx context.Context, topic string) ([]*event.Delivery, error) {
+	stream := s.opts.prefix + topic
+	if err := s.createGroup(ctx, stream); err != nil {
+		return nil, err
+	}
+	ds, err := s.claim(ctx, topic, stream)
+	if err != nil || len(ds) > 0 {
+		return ds, err
+	}
+
+	res, err := s.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    s.group,
+		Consumer: s.consumer,
+		Streams:  []string{stream, ">"},
+		Count:    s.opts.batchSize,
+		Block:    s.opts.block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s failed: %w", stream, err)
+	}
+	for _, r := range res {
+		for _, m := range r.Messages {
+			ds = append(ds, toDelivery(topic, m, 1))
+		}
+	}
+	return ds, nil
+}
+
+// createGroup creates the consumer group of stream once.
+func (s *Subscriber) createGroup(ctx context.Context, stream string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.streams[stream] {
+		return nil
+	}
+	err := s.rdb.XGroupCreateMkStream(ctx, stream, s.group, s.opts.startID).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("create group %s of %s failed: %w", s.group, stream, err)
+	}
+	s.streams[stream] = true
+	return nil
+}
+
+// claim claims the messages pending for longer than the retry delay.
+func (s *Subscriber) claim(ctx context.Context, topic, stream string) ([]*event.Delivery, error) {
+	pending, err := s.rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  s.group,
+		Idle:   s.opts.retryDelay,
+		Start:  "-",
+		End:    "+",
+		Count:  s.opts.batchSize,
+	}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("list pending of %s failed: %w", stream, err)
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(pending))
+	attempts := make(map[string]int, len(pending))
+	for _, p := range pending {
+		ids = append(ids, p.ID)
+		attempts[p.ID] = int(p.RetryCount) + 1
+	}
+	msgs, err := s.rdb.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    s.group,
+		Consumer: s.consumer,
+		MinIdle:  s.opts.retryDelay,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("claim pending of %s failed: %w", stream, err)
+	}
+
+	ds := make([]*event.Delivery, 0, len(msgs))
+	claimed := make(map[string]bool, len(msgs))
+	for _, m := range msgs {
+		claimed[m.ID] = true
+		ds = append(ds, toDelivery(topic, m, attempts[m.ID]))
+	}
+	// Entries trimmed from the stream cannot be delivered anymore.
+	var trimmed []string
+	for _, id := range ids {
+		if !claimed[id] {
+			trimmed = append(trimmed, id)
+		}
+	}
+	if len(trimmed) > 0 {
+		if err := s.rdb.XAck(ctx, stream, s.group, trimmed...).Err(); err != nil {
+			return nil, fmt.Errorf("ack trimmed entries of %s failed: %w", stream, err)
+		}
+	}
+	return ds, nil
+}
+
+// Close implements event.SubscriberCloser. It deletes the consumer from the
+// groups of the streams it read, unless messages are still pending for it,
+// which other consumers claim after the retry delay.
+func (s *Subscriber) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for stream := range s.streams {
+		pending, err := s.rdb.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream:   stream,
+			Group:    s.group,
+			Start:    "-",
+			End:      "+",
+			Count:    1,
+			Consumer: s.consumer,
+		}).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			errs = append(errs, fmt.Errorf("list pending of %s failed: %w", stream, err))
+			continue
+		}
+		if len(pending) > 0 {
+			continue
+		}
+		if err := s.rdb.XGroupDelConsumer(ctx, stream, s.group, s.consumer).Err(); err != nil {
+			errs = append(errs, fmt.Errorf("delete consumer %s of %s failed: %w", s.consumer, stream, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Ack implements event.Subscriber.
+func (s *Subscriber) Ack(ctx context.Context, d *event.Delivery) error {
+	stream := s.opts.prefix + d.Topic
+	if err := s.rdb.XAck(ctx, stream, s.group, d.Receipt).Err(); err != nil {
+		return fmt.Errorf("ack %s of %s failed: %w", d.Receipt, stream, err)
+	}
+	return nil
+}
+
+// DeadLetter implements event.Subscriber. The dead letter is added to the
+// stream of the dead-letter topic.
+func (s *Subscriber) DeadLetter(ctx context.Context, d *event.Delivery, cause error) error {
+	if err := publish(ctx, s.rdb, s.opts, event.DeadLetterMessage(d, cause)); err != nil {
+		return err
+	}
+	return s.Ack(ctx, d)
+}
+
+func toDelivery(topic string, m redis.XMessage, attempt int) *event.Delivery {
+	d := &event.Delivery{
+		Message: event.Message{
+			ID:      stringValue(m.Values[fieldID]),
+			Topic:   topic,
+			Key:     stringValue(m.Values[fieldKey]),
+			Payload: []byte(stringValue(m.Values[fieldPayload])),
+		},
+		Attempt: attempt,
+		Receipt: m.ID,
+	}
+	if headers := stringValue(m.Values[fieldHeaders]); headers != "" {
+		// Malformed headers are dropped rather than blocking the message.
+		_ = json.Unmarshal([]byte(headers), &d.Headers)
+	}
+	return d
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
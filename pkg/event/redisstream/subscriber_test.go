@@ -0,0 +1,129 @@
+package redisstream
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-kratos/kratos-layout/pkg/event"
+)
+
+func TestSubscriber(t *testing.T) {
+	mr := miniredis.RunT(t)
+	now := time.Now()
+	mr.SetTime(now)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	ctx := context.Background()
+
+	pub := NewPublisher(rdb)
+	sub := NewSubscriber(rdb, "g", "c1", WithBlock(10*time.Millisecond), WithRetryDelay(time.Minute))
+	other := NewSubscriber(rdb, "g", "c2", WithBlock(10*time.Millisecond), WithRetryDelay(time.Minute))
+
+	// The group starts at the start of the stream, so messages published
+	// before it was created are received.
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "1", Topic: "t", Key: "a", Payload: []byte("x"),
+		Headers: map[string]string{"X-Request-ID": "r1"}}))
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "2", Topic: "t", Key: "b"}))
+
+	ds, err := sub.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+	require.Equal(t, event.Message{ID: "1", Topic: "t", Key: "a", Payload: []byte("x"),
+		Headers: map[string]string{"X-Request-ID": "r1"}}, ds[0].Message)
+	require.Equal(t, 1, ds[0].Attempt)
+	require.NotEmpty(t, ds[0].Receipt)
+
+	// Each message goes to one consumer of the group.
+	none, err := other.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	// The unacknowledged message is delivered again after the retry delay,
+	// to any consumer.
+	require.NoError(t, sub.Ack(ctx, ds[0]))
+	mr.SetTime(now.Add(2 * time.Minute))
+	retried, err := other.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	require.Equal(t, "2", retried[0].ID)
+	require.Equal(t, 2, retried[0].Attempt)
+
+	require.NoError(t, other.DeadLetter(ctx, retried[0], errors.New("boom")))
+	dead, err := rdb.XRange(ctx, "stream:"+event.DeadLetterTopic("t"), "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, "2", dead[0].Values["id"])
+	require.JSONEq(t, `{"Dead-Letter-Error":"boom","Dead-Letter-Attempts":"2"}`, dead[0].Values["headers"].(string))
+
+	mr.SetTime(now.Add(4 * time.Minute))
+	pending, err := rdb.XPending(ctx, "stream:t", "g").Result()
+	require.NoError(t, err)
+	require.Zero(t, pending.Count)
+}
+
+func TestSubscriber_StartID(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	ctx := context.Background()
+
+	pub := NewPublisher(rdb)
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "1", Topic: "t"}))
+
+	// A group starting at the end of the stream skips earlier messages.
+	sub := NewSubscriber(rdb, "g", "c1", WithBlock(10*time.Millisecond), WithStartID("$"))
+	ds, err := sub.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Empty(t, ds)
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "2", Topic: "t"}))
+	ds, err = sub.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	require.Equal(t, "2", ds[0].ID)
+}
+
+func TestSubscriber_Close(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	ctx := context.Background()
+
+	pub := NewPublisher(rdb)
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "1", Topic: "t"}))
+	require.NoError(t, pub.Publish(ctx, &event.Message{ID: "2", Topic: "t"}))
+	consumers := func() []string {
+		cs, err := rdb.XInfoConsumers(ctx, "stream:t", "g").Result()
+		require.NoError(t, err)
+		names := make([]string, 0, len(cs))
+		for _, c := range cs {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	idle := NewSubscriber(rdb, "g", "idle", WithBlock(10*time.Millisecond), WithBatchSize(1))
+	busy := NewSubscriber(rdb, "g", "busy", WithBlock(10*time.Millisecond), WithBatchSize(1))
+	ds, err := busy.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, ds, 1)
+	acked, err := idle.Receive(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, acked, 1)
+	require.NoError(t, idle.Ack(ctx, acked[0]))
+	require.ElementsMatch(t, []string{"idle", "busy"}, consumers())
+
+	// A consumer with pending messages stays until they are acknowledged.
+	require.NoError(t, idle.Close(ctx))
+	require.NoError(t, busy.Close(ctx))
+	require.Equal(t, []string{"busy"}, consumers())
+
+	require.NoError(t, busy.Ack(ctx, ds[0]))
+	require.NoError(t, busy.Close(ctx))
+	require.Empty(t, consumers())
+}
@@ -0,0 +1,265 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+)
+
+// KindEvent is the transport.Kind of Server.
+const KindEvent transport.Kind = "event"
+
+const (
+	// DefaultMaxAttempts is how often a message is delivered before it is
+	// dead-lettered.
+	DefaultMaxAttempts = 5
+	// receiveRetryInterval is the pause after a failed Receive.
+	receiveRetryInterval = time.Second
+)
+
+var (
+	_ transport.Server      = (*Server)(nil)
+	_ transport.Transporter = (*Transport)(nil)
+)
+
+// Option is server option.
+type Option func(*options)
+
+type options struct {
+	middleware  []middleware.Middleware
+	maxAttempts int
+	logger      log.Logger
+}
+
+// WithMiddleware sets the middleware handlers run through, in order.
+func WithMiddleware(m ...middleware.Middleware) Option {
+	return func(o *options) {
+		o.middleware = m
+	}
+}
+
+// WithMaxAttempts sets how often a message is delivered before it is
+// dead-lettered, DefaultMaxAttempts by default.
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// WithLogger sets the logger of the server.
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// handler handles a delivery, and returns a malformedError when its payload
+// cannot be decoded.
+type handler func(ctx context.Context, d *Delivery) error
+
+// malformedError is returned for messages no delivery can handle.
+type malformedError struct {
+	err error
+}
+
+func (e *malformedError) Error() string { return e.err.Error() }
+func (e *malformedError) Unwrap() error { return e.err }
+
+// Server is a transport.Server consuming the messages of a Subscriber.
+// Messages are acknowledged when their handler returns nil, and delivered
+// again when it fails, until they were delivered WithMaxAttempts times and
+// are dead-lettered. Messages whose payload cannot be decoded are
+// dead-lettered right away.
+type Server struct {
+	sub      Subscriber
+	opts     options
+	handlers map[string]handler
+	log      *log.Helper
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewServer creates a Server consuming the messages of sub.
+func NewServer(sub Subscriber, opts ...Option) *Server {
+	o := options{
+		maxAttempts: DefaultMaxAttempts,
+		logger:      log.GetLogger(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Server{
+		sub:      sub,
+		opts:     o,
+		handlers: make(map[string]handler),
+		log:      log.NewHelper(log.With(o.logger, "module", "event/server")),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Subscribe registers h for the topic named after the full name of T. The
+// payloads of its messages are unmarshaled into T and passed to h through
+// the middleware of s. Subscribe must be called before s starts.
+func Subscribe[T any, PT interface {
+	*T
+	proto.Message
+}](s *Server, h func(context.Context, PT) error) {
+	topic := string(PT(new(T)).ProtoReflect().Descriptor().FullName())
+	next := middleware.Chain(s.opts.middleware...)(func(ctx context.Context, req any) (any, error) {
+		return nil, h(ctx, req.(PT))
+	})
+	s.handlers[topic] = func(ctx context.Context, d *Delivery) error {
+		in := PT(new(T))
+		if err := proto.Unmarshal(d.Payload, in); err != nil {
+			return &malformedError{fmt.Errorf("unmarshal %s failed: %w", topic, err)}
+		}
+		_, err := next(transport.NewServerContext(ctx, newTransport(d)), in)
+		return err
+	}
+}
+
+// Start consumes the subscribed topics until Stop is called.
+func (s *Server) Start(ctx context.Context) error {
+	defer close(s.done)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for topic, h := range s.handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.consume(ctx, topic, h)
+		}()
+	}
+	wg.Wait()
+	if c, ok := s.sub.(SubscriberCloser); ok {
+		if err := c.Close(context.WithoutCancel(ctx)); err != nil {
+			s.log.WithContext(ctx).Errorf("close subscriber failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// Stop stops receiving messages and waits for the handlers in progress.
+func (s *Server) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) consume(ctx context.Context, topic string, h handler) {
+	for {
+		ds, err := s.sub.Receive(ctx, topic)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			s.log.Errorf("receive %s failed: %v", topic, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(receiveRetryInterval):
+			}
+			continue
+		}
+		for _, d := range ds {
+			// Handlers finish the deliveries received before Stop.
+			s.deliver(context.WithoutCancel(ctx), h, d)
+		}
+	}
+}
+
+// deliver handles d, then acknowledges it, leaves it for redelivery or
+// dead-letters it.
+func (s *Server) deliver(ctx context.Context, h handler, d *Delivery) {
+	err := h(ctx, d)
+	var malformed *malformedError
+	switch {
+	case err == nil:
+		err = s.sub.Ack(ctx, d)
+	case errors.As(err, &malformed) || d.Attempt >= s.opts.maxAttempts:
+		s.log.Warnf("dead-letter message %s of %s after %d attempts: %v", d.ID, d.Topic, d.Attempt, err)
+		err = s.sub.DeadLetter(ctx, d, err)
+	default:
+		s.log.Warnf("handle message %s of %s failed in attempt %d: %v", d.ID, d.Topic, d.Attempt, err)
+		return
+	}
+	if err != nil {
+		s.log.Errorf("settle message %s of %s failed: %v", d.ID, d.Topic, err)
+	}
+}
+
+// Transport is the transport.Transporter of a delivery handled by Server.
+// Its request header holds the message headers.
+type Transport struct {
+	delivery    *Delivery
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+func newTransport(d *Delivery) *Transport {
+	h := make(headerCarrier, len(d.Headers))
+	for k, v := range d.Headers {
+		h.Set(k, v)
+	}
+	return &Transport{delivery: d, reqHeader: h, replyHeader: headerCarrier{}}
+}
+
+// Kind returns KindEvent.
+func (t *Transport) Kind() transport.Kind { return KindEvent }
+
+// Endpoint returns an empty endpoint.
+func (t *Transport) Endpoint() string { return "" }
+
+// Operation returns the topic of the message.
+func (t *Transport) Operation() string { return t.delivery.Topic }
+
+// RequestHeader returns the message headers.
+func (t *Transport) RequestHeader() transport.Header { return t.reqHeader }
+
+// ReplyHeader returns a header that is discarded.
+func (t *Transport) ReplyHeader() transport.Header { return t.replyHeader }
+
+// Delivery returns the delivery being handled.
+func (t *Transport) Delivery() *Delivery { return t.delivery }
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
@@ -0,0 +1,176 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const topicString = "google.protobuf.StringValue"
+
+func publishString(t *testing.T, b *MemoryBroker, id, value string) {
+	t.Helper()
+	payload, err := proto.Marshal(wrapperspb.String(value))
+	require.NoError(t, err)
+	require.NoError(t, b.Publish(context.Background(), &Message{
+		ID:      id,
+		Topic:   topicString,
+		Payload: payload,
+		Headers: map[string]string{"X-Request-ID": "req-" + id},
+	}))
+}
+
+// pending returns the number of unacknowledged deliveries of topic.
+func pending(b *MemoryBroker, topic string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending[topic])
+}
+
+func startServer(t *testing.T, srv *Server) {
+	t.Helper()
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Start(context.Background()) }()
+	t.Cleanup(func() {
+		require.NoError(t, srv.Stop(context.Background()))
+		require.NoError(t, <-errc)
+	})
+}
+
+func TestServer(t *testing.T) {
+	b := NewMemoryBroker()
+	// seen is the transport a middleware found in the context. The
+	// middleware runs on the server's goroutines, so the test checks it
+	// afterwards.
+	type seen struct {
+		ok         bool
+		kind       transport.Kind
+		operation  string
+		deliveryID string
+		requestID  string
+	}
+	var (
+		mu      sync.Mutex
+		handled []string
+		seens   []seen
+	)
+	record := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			var s seen
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				s = seen{ok: true, kind: tr.Kind(), operation: tr.Operation(), requestID: tr.RequestHeader().Get("X-Request-ID")}
+				if tr, ok := tr.(*Transport); ok {
+					s.deliveryID = tr.Delivery().ID
+				}
+			}
+			mu.Lock()
+			seens = append(seens, s)
+			mu.Unlock()
+			return handler(ctx, req)
+		}
+	}
+	srv := NewServer(b, WithMiddleware(record), WithMaxAttempts(3))
+	Subscribe(srv, func(ctx context.Context, in *wrapperspb.StringValue) error {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, in.Value)
+		if in.Value == "fail" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	startServer(t, srv)
+
+	publishString(t, b, "1", "ok")
+	publishString(t, b, "2", "fail")
+	require.NoError(t, b.Publish(context.Background(), &Message{ID: "3", Topic: topicString, Payload: []byte{0xff}}))
+
+	// The failing message is dead-lettered after 3 attempts, and the
+	// malformed one right away.
+	require.Eventually(t, func() bool {
+		return len(b.Messages(DeadLetterTopic(topicString))) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	dead := b.Messages(DeadLetterTopic(topicString))
+	require.Equal(t, "3", dead[0].ID)
+	require.Contains(t, dead[0].Headers[HeaderDeadLetterError], "unmarshal google.protobuf.StringValue failed")
+	require.Equal(t, "2", dead[1].ID)
+	require.Equal(t, "boom", dead[1].Headers[HeaderDeadLetterError])
+	require.Equal(t, "3", dead[1].Headers[HeaderDeadLetterAttempts])
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"ok", "fail", "fail", "fail"}, handled)
+	require.Len(t, seens, 4)
+	for _, s := range seens {
+		require.True(t, s.ok)
+		require.Equal(t, KindEvent, s.kind)
+		require.Equal(t, topicString, s.operation)
+		require.Equal(t, "req-"+s.deliveryID, s.requestID)
+	}
+
+	require.Zero(t, pending(b, topicString))
+}
+
+func TestServer_Stop(t *testing.T) {
+	b := NewMemoryBroker()
+	srv := NewServer(b)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	Subscribe(srv, func(ctx context.Context, in *wrapperspb.StringValue) error {
+		close(started)
+		<-release
+		return ctx.Err()
+	})
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Start(context.Background()) }()
+	publishString(t, b, "1", "slow")
+	<-started
+
+	// Stop waits for the handler in progress, which is not canceled.
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Stop(context.Background()) }()
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned before the handler finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	require.NoError(t, <-stopped)
+	require.NoError(t, <-errc)
+	require.Zero(t, pending(b, topicString))
+}
+
+// closingBroker records whether the Server closed it.
+type closingBroker struct {
+	*MemoryBroker
+	closed chan struct{}
+}
+
+func (b *closingBroker) Close(context.Context) error {
+	close(b.closed)
+	return nil
+}
+
+func TestServer_ClosesSubscriber(t *testing.T) {
+	b := &closingBroker{MemoryBroker: NewMemoryBroker(), closed: make(chan struct{})}
+	srv := NewServer(b)
+	Subscribe(srv, func(context.Context, *wrapperspb.StringValue) error { return nil })
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Start(context.Background()) }()
+
+	require.NoError(t, srv.Stop(context.Background()))
+	require.NoError(t, <-errc)
+	select {
+	case <-b.closed:
+	default:
+		t.Fatal("Stop returned before the subscriber was closed")
+	}
+}
@@ -0,0 +1,46 @@
+package log
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Reasons recorded on the dropped log lines metric.
+const (
+	DropReasonSampled     = "sampled"
+	DropReasonRateLimited = "rate_limited"
+)
+
+// MetricDroppedLines is the name of the counter of dropped log lines.
+const MetricDroppedLines = "log_dropped_lines_total"
+
+var (
+	droppedOnce    sync.Once
+	droppedCounter metric.Int64Counter
+)
+
+// recordDropped increments the dropped log lines counter.
+// The counter is registered on the global otel meter provider on first use.
+func recordDropped(reason, level string) {
+	droppedOnce.Do(func() {
+		var err error
+		droppedCounter, err = otel.Meter("github.com/go-kratos/kratos-layout/pkg/log").Int64Counter(
+			MetricDroppedLines,
+			metric.WithDescription("The total number of log lines dropped by sampling or rate limiting."),
+		)
+		if err != nil {
+			otel.Handle(err)
+		}
+	})
+	if droppedCounter == nil {
+		return
+	}
+	droppedCounter.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("reason", reason),
+		attribute.String("level", level),
+	))
+}
@@ -0,0 +1,149 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// RateLimiter allows at most limit events per interval for each key.
+type RateLimiter struct {
+	limit    int
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+	dropped   atomic.Uint64
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// NewRateLimiter creates a RateLimiter that allows limit events per interval for each key.
+func NewRateLimiter(limit int, interval time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:    limit,
+		interval: interval,
+		now:      time.Now,
+		windows:  make(map[string]*window),
+	}
+}
+
+// Allow reports whether an event for key may happen now.
+func (r *RateLimiter) Allow(key string) bool {
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(now)
+
+	w, ok := r.windows[key]
+	if !ok || now.Sub(w.start) >= r.interval {
+		w = &window{start: now}
+		r.windows[key] = w
+	}
+	if w.count >= r.limit {
+		r.dropped.Add(1)
+		return false
+	}
+	w.count++
+	return true
+}
+
+// Dropped returns the number of events rejected so far.
+func (r *RateLimiter) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// sweep removes expired windows at most once per interval so that keys
+// which stop appearing do not leak memory.
+func (r *RateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.interval {
+		return
+	}
+	for key, w := range r.windows {
+		if now.Sub(w.start) >= r.interval {
+			delete(r.windows, key)
+		}
+	}
+	r.lastSweep = now
+}
+
+// RateLimitedHelper is a log helper that logs at most limit lines per interval for each key.
+// Lines over the limit are dropped and counted in the dropped log lines metric.
+type RateLimitedHelper struct {
+	logger  log.Logger
+	limiter *RateLimiter
+}
+
+// NewRateLimitedHelper creates a RateLimitedHelper.
+func NewRateLimitedHelper(logger log.Logger, limit int, interval time.Duration) *RateLimitedHelper {
+	return &RateLimitedHelper{
+		logger:  logger,
+		limiter: NewRateLimiter(limit, interval),
+	}
+}
+
+// WithContext returns a shallow copy of h with its context changed to ctx.
+// The copy shares the rate limiter with h.
+func (h *RateLimitedHelper) WithContext(ctx context.Context) *RateLimitedHelper {
+	return &RateLimitedHelper{
+		logger:  log.WithContext(ctx, h.logger),
+		limiter: h.limiter,
+	}
+}
+
+// Dropped returns the number of lines dropped by this helper.
+func (h *RateLimitedHelper) Dropped() uint64 {
+	return h.limiter.Dropped()
+}
+
+// Debugf logs a message at debug level unless key exceeded its limit.
+func (h *RateLimitedHelper) Debugf(key, format string, a ...any) {
+	if !h.allow(key, log.LevelDebug) {
+		return
+	}
+	_ = h.logger.Log(log.LevelDebug, log.DefaultMessageKey, fmt.Sprintf(format, a...))
+}
+
+// Infof logs a message at info level unless key exceeded its limit.
+func (h *RateLimitedHelper) Infof(key, format string, a ...any) {
+	if !h.allow(key, log.LevelInfo) {
+		return
+	}
+	_ = h.logger.Log(log.LevelInfo, log.DefaultMessageKey, fmt.Sprintf(format, a...))
+}
+
+// Warnf logs a message at warn level unless key exceeded its limit.
+func (h *RateLimitedHelper) Warnf(key, format string, a ...any) {
+	if !h.allow(key, log.LevelWarn) {
+		return
+	}
+	_ = h.logger.Log(log.LevelWarn, log.DefaultMessageKey, fmt.Sprintf(format, a...))
+}
+
+// Errorf logs a message at error level unless key exceeded its limit.
+func (h *RateLimitedHelper) Errorf(key, format string, a ...any) {
+	if !h.allow(key, log.LevelError) {
+		return
+	}
+	_ = h.logger.Log(log.LevelError, log.DefaultMessageKey, fmt.Sprintf(format, a...))
+}
+
+func (h *RateLimitedHelper) allow(key string, level log.Level) bool {
+	if h.limiter.Allow(key) {
+		return true
+	}
+	recordDropped(DropReasonRateLimited, strings.ToLower(level.String()))
+	return false
+}
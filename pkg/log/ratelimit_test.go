@@ -0,0 +1,63 @@
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter(2, time.Second)
+	limiter.now = func() time.Time { return now }
+
+	require.True(t, limiter.Allow("a"))
+	require.True(t, limiter.Allow("a"))
+	require.False(t, limiter.Allow("a"))
+
+	// Keys are limited independently.
+	require.True(t, limiter.Allow("b"))
+
+	// A new interval resets the window.
+	now = now.Add(time.Second)
+	require.True(t, limiter.Allow("a"))
+
+	require.Equal(t, uint64(1), limiter.Dropped())
+}
+
+func TestRateLimiter_Sweep(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter(1, time.Second)
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("a")
+	limiter.Allow("b")
+	require.Len(t, limiter.windows, 2)
+
+	now = now.Add(2 * time.Second)
+	limiter.Allow("c")
+	require.Len(t, limiter.windows, 1)
+}
+
+func TestRateLimitedHelper(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := &ZapLogger{log: zap.New(core)}
+
+	helper := NewRateLimitedHelper(logger, 2, time.Minute)
+	for i := 0; i < 5; i++ {
+		helper.Infof("create", "create %d", i)
+		helper.WithContext(context.Background()).Errorf("fail", "fail %d", i)
+	}
+	helper.Debugf("debug", "debug")
+	helper.Warnf("warn", "warn")
+
+	require.Equal(t, 2, logs.FilterMessageSnippet("create").Len())
+	require.Equal(t, 2, logs.FilterMessageSnippet("fail").Len())
+	require.Equal(t, 6, logs.Len())
+	require.Equal(t, uint64(6), helper.Dropped())
+}
@@ -0,0 +1,209 @@
+package log
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultMask replaces redacted values.
+const DefaultMask = "******"
+
+// DefaultRedactKeys are the keys masked by a default Redactor.
+// Keys are matched case-insensitively, ignoring '-' and '_'.
+var DefaultRedactKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"authorization",
+	"api_key",
+	"cookie",
+}
+
+// DefaultRedactPatterns are the value patterns masked by a default Redactor.
+var DefaultRedactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)bearer\s+[a-z0-9\-._~+/]+=*`),
+	regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+}
+
+// RedactOption is Redactor option.
+type RedactOption func(*Redactor)
+
+// WithRedactKeys replaces the set of masked keys.
+func WithRedactKeys(keys ...string) RedactOption {
+	return func(r *Redactor) {
+		r.keys = make(map[string]struct{}, len(keys))
+		for _, k := range keys {
+			r.keys[normalizeKey(k)] = struct{}{}
+		}
+	}
+}
+
+// WithRedactPatterns replaces the set of masked value patterns.
+func WithRedactPatterns(patterns ...*regexp.Regexp) RedactOption {
+	return func(r *Redactor) {
+		r.patterns = patterns
+	}
+}
+
+// WithMask sets the replacement for redacted values.
+func WithMask(mask string) RedactOption {
+	return func(r *Redactor) {
+		r.mask = mask
+	}
+}
+
+// Redactor masks values of sensitive keys and substrings of values that match sensitive patterns.
+type Redactor struct {
+	keys     map[string]struct{}
+	patterns []*regexp.Regexp
+	mask     string
+}
+
+// NewRedactor creates a Redactor using DefaultRedactKeys and DefaultRedactPatterns unless overridden.
+func NewRedactor(opts ...RedactOption) *Redactor {
+	r := &Redactor{
+		patterns: DefaultRedactPatterns,
+		mask:     DefaultMask,
+	}
+	WithRedactKeys(DefaultRedactKeys...)(r)
+	for _, o := range opts {
+		o(r)
+	}
+	return r
+}
+
+// keySeparators strips the separators ignored when matching keys.
+var keySeparators = strings.NewReplacer("_", "", "-", "")
+
+// normalizeKey lowercases key and strips separators so that
+// "Access-Token", "accessToken" and "access_token" match alike.
+func normalizeKey(key string) string {
+	return keySeparators.Replace(strings.ToLower(key))
+}
+
+// IsSensitiveKey reports whether values of key must be masked.
+func (r *Redactor) IsSensitiveKey(key string) bool {
+	_, ok := r.keys[normalizeKey(key)]
+	return ok
+}
+
+// RedactString masks every substring of s that matches a sensitive pattern.
+func (r *Redactor) RedactString(s string) string {
+	for _, p := range r.patterns {
+		s = p.ReplaceAllString(s, r.mask)
+	}
+	return s
+}
+
+// RedactKeyValue returns the masked form of value logged under key.
+func (r *Redactor) RedactKeyValue(key, value string) string {
+	if r.IsSensitiveKey(key) {
+		return r.mask
+	}
+	return r.RedactString(value)
+}
+
+// RedactJSON masks sensitive keys at any depth of a JSON document and
+// sensitive patterns in its string values. Invalid JSON is redacted as a plain string.
+func (r *Redactor) RedactJSON(data []byte) []byte {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return []byte(r.RedactString(string(data)))
+	}
+	out, err := json.Marshal(r.redactValue(v))
+	if err != nil {
+		return []byte(r.RedactString(string(data)))
+	}
+	return out
+}
+
+// RedactMap masks sensitive keys and patterns in a string map, such as error metadata.
+func (r *Redactor) RedactMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = r.RedactKeyValue(k, v)
+	}
+	return out
+}
+
+func (r *Redactor) redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			if r.IsSensitiveKey(k) {
+				val[k] = r.mask
+				continue
+			}
+			val[k] = r.redactValue(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = r.redactValue(item)
+		}
+		return val
+	case string:
+		return r.RedactString(val)
+	default:
+		return val
+	}
+}
+
+// WithRedaction returns a zap option that masks sensitive fields and
+// patterns in log messages before they are encoded.
+func WithRedaction(r *Redactor) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if r == nil {
+			return core
+		}
+		return &redactCore{Core: core, redactor: r}
+	})
+}
+
+type redactCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redactFields(fields)), redactor: c.redactor}
+}
+
+// Check lets the wrapped core decide whether to log the entry, so that
+// sampling is honored regardless of option order, then writes through c.
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Check(ent, nil) == nil {
+		return ce
+	}
+	return ce.AddCore(ent, c)
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.redactor.RedactString(ent.Message)
+	return c.Core.Write(ent, c.redactFields(fields))
+}
+
+func (c *redactCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch {
+		case c.redactor.IsSensitiveKey(f.Key):
+			out[i] = zap.String(f.Key, c.redactor.mask)
+		case f.Type == zapcore.StringType:
+			out[i] = zap.String(f.Key, c.redactor.RedactString(f.String))
+		default:
+			out[i] = f
+		}
+	}
+	return out
+}
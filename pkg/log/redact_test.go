@@ -0,0 +1,91 @@
+package log
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactor_IsSensitiveKey(t *testing.T) {
+	r := NewRedactor()
+
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{"password", true},
+		{"Password", true},
+		{"access_token", true},
+		{"Access-Token", true},
+		{"accessToken", true},
+		{"Authorization", true},
+		{"name", false},
+		{"token_type", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			require.Equal(t, tt.expected, r.IsSensitiveKey(tt.key))
+		})
+	}
+}
+
+func TestRedactor_RedactString(t *testing.T) {
+	r := NewRedactor()
+
+	require.Equal(t, "auth "+DefaultMask, r.RedactString("auth Bearer abc.def-123"))
+	require.Equal(t, "mail "+DefaultMask+" now", r.RedactString("mail john.doe@example.com now"))
+	require.Equal(t, "nothing here", r.RedactString("nothing here"))
+}
+
+func TestRedactor_RedactJSON(t *testing.T) {
+	r := NewRedactor(WithRedactPatterns(regexp.MustCompile(`\d{16}`)), WithMask("[x]"))
+
+	in := `{"name":"kratos","password":"p@ss","nested":{"Token":"abc","cards":["4111111111111111"]}}`
+	out := r.RedactJSON([]byte(in))
+
+	require.JSONEq(t, `{"name":"kratos","password":"[x]","nested":{"Token":"[x]","cards":["[x]"]}}`, string(out))
+	require.Equal(t, "[x] plain", string(r.RedactJSON([]byte("4111111111111111 plain"))))
+}
+
+func TestRedactor_RedactMap(t *testing.T) {
+	r := NewRedactor(WithRedactKeys("secret"))
+
+	require.Nil(t, r.RedactMap(nil))
+	require.Equal(t,
+		map[string]string{"secret": DefaultMask, "password": "kept"},
+		r.RedactMap(map[string]string{"secret": "s", "password": "kept"}),
+	)
+}
+
+func TestWithRedaction(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := &ZapLogger{log: zap.New(core, WithRedaction(NewRedactor())).With(zap.String("token", "abc"))}
+
+	helper := log.NewHelper(logger)
+	helper.Infow(log.DefaultMessageKey, "login bob@example.com", "password", "secret", "user", "bob")
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	require.Equal(t, "login "+DefaultMask, entry.Message)
+	require.Equal(t, map[string]any{
+		"token":    DefaultMask,
+		"password": DefaultMask,
+		"user":     "bob",
+	}, entry.ContextMap())
+}
+
+func TestWithRedaction_Sampling(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	cfg := &SamplingConfig{Rules: map[zapcore.Level]SamplingRule{zapcore.InfoLevel: {Initial: 1}}}
+	logger := zap.New(core, WithSampling(cfg), WithRedaction(NewRedactor()))
+
+	logger.Info("same")
+	logger.Info("same")
+	require.Equal(t, 1, logs.Len())
+}
@@ -0,0 +1,113 @@
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingRule controls how repeated entries are sampled within one tick.
+// The first Initial entries with the same level and message are logged,
+// then every Thereafter-th entry. A zero Thereafter drops all entries after
+// the first Initial ones.
+type SamplingRule struct {
+	Initial    int
+	Thereafter int
+}
+
+// SamplingConfig is the per-level sampling configuration.
+// Levels without a rule are never sampled.
+type SamplingConfig struct {
+	Tick  time.Duration
+	Rules map[zapcore.Level]SamplingRule
+}
+
+// DefaultSamplingConfig samples debug and info entries at 100/100 per second
+// and keeps every warning and error.
+func DefaultSamplingConfig() *SamplingConfig {
+	return &SamplingConfig{
+		Tick: time.Second,
+		Rules: map[zapcore.Level]SamplingRule{
+			zapcore.DebugLevel: {Initial: 100, Thereafter: 100},
+			zapcore.InfoLevel:  {Initial: 100, Thereafter: 100},
+		},
+	}
+}
+
+// NewSamplingConfig creates a SamplingConfig from rules keyed by level name,
+// such as "debug" or "info".
+func NewSamplingConfig(tick time.Duration, rules map[string]SamplingRule) (*SamplingConfig, error) {
+	c := &SamplingConfig{Tick: tick, Rules: make(map[zapcore.Level]SamplingRule, len(rules))}
+	for name, rule := range rules {
+		lvl, err := zapcore.ParseLevel(name)
+		if err != nil {
+			return nil, err
+		}
+		if rule.Initial < 0 || rule.Thereafter < 0 {
+			return nil, fmt.Errorf("sampling rule of level %s is negative", name)
+		}
+		c.Rules[lvl] = rule
+	}
+	return c, nil
+}
+
+// getTick returns the sampling tick, defaulting to 1 second
+func (c *SamplingConfig) getTick() time.Duration {
+	if c.Tick <= 0 {
+		return time.Second
+	}
+	return c.Tick
+}
+
+// WithSampling returns a zap option that applies per-level sampling.
+// Dropped entries are counted in the dropped log lines metric.
+func WithSampling(c *SamplingConfig) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return newLevelSampler(core, c)
+	})
+}
+
+// levelSampler dispatches entries to a sampled core for their level,
+// falling back to the unsampled core for levels without a rule.
+type levelSampler struct {
+	zapcore.Core
+	sampled map[zapcore.Level]zapcore.Core
+}
+
+func newLevelSampler(core zapcore.Core, c *SamplingConfig) zapcore.Core {
+	if c == nil || len(c.Rules) == 0 {
+		return core
+	}
+
+	hook := zapcore.SamplerHook(func(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+		if dec&zapcore.LogDropped != 0 {
+			recordDropped(DropReasonSampled, ent.Level.String())
+		}
+	})
+
+	sampled := make(map[zapcore.Level]zapcore.Core, len(c.Rules))
+	for lvl, rule := range c.Rules {
+		sampled[lvl] = zapcore.NewSamplerWithOptions(core, c.getTick(), rule.Initial, rule.Thereafter, hook)
+	}
+	return &levelSampler{Core: core, sampled: sampled}
+}
+
+// With adds structured context to every underlying core.
+// Sampling counters are shared with the parent.
+func (s *levelSampler) With(fields []zapcore.Field) zapcore.Core {
+	sampled := make(map[zapcore.Level]zapcore.Core, len(s.sampled))
+	for lvl, core := range s.sampled {
+		sampled[lvl] = core.With(fields)
+	}
+	return &levelSampler{Core: s.Core.With(fields), sampled: sampled}
+}
+
+// Check routes the entry to the sampler configured for its level.
+func (s *levelSampler) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if core, ok := s.sampled[ent.Level]; ok {
+		return core.Check(ent, ce)
+	}
+	return s.Core.Check(ent, ce)
+}
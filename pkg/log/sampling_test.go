@@ -0,0 +1,97 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithSampling(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	cfg := &SamplingConfig{
+		Tick: time.Minute,
+		Rules: map[zapcore.Level]SamplingRule{
+			zapcore.InfoLevel: {Initial: 2, Thereafter: 3},
+		},
+	}
+	logger := zap.New(core, WithSampling(cfg))
+
+	for i := 0; i < 8; i++ {
+		logger.Info("hot path")
+		logger.Warn("hot path")
+	}
+
+	// Info: entries 1, 2, 5 and 8 are kept; warnings are never sampled.
+	require.Equal(t, 4, logs.FilterLevelExact(zapcore.InfoLevel).Len())
+	require.Equal(t, 8, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+}
+
+func TestWithSampling_DistinctMessages(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	cfg := &SamplingConfig{
+		Tick: time.Minute,
+		Rules: map[zapcore.Level]SamplingRule{
+			zapcore.InfoLevel: {Initial: 1, Thereafter: 0},
+		},
+	}
+	logger := zap.New(core, WithSampling(cfg)).With(zap.String("service", "test"))
+
+	logger.Info("first")
+	logger.Info("first")
+	logger.Info("second")
+
+	require.Equal(t, 2, logs.Len())
+	require.Equal(t, "test", logs.All()[0].ContextMap()["service"])
+}
+
+func TestWithSampling_NilConfig(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core, WithSampling(nil))
+
+	for i := 0; i < 5; i++ {
+		logger.Info("not sampled")
+	}
+	require.Equal(t, 5, logs.Len())
+}
+
+func TestZapLogger_MessageKey(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := &ZapLogger{log: zap.New(core)}
+
+	helper := log.NewHelper(logger)
+	helper.Infof("hello %s", "kratos")
+
+	require.Equal(t, 1, logs.Len())
+	require.Equal(t, "hello kratos", logs.All()[0].Message)
+	require.NotContains(t, logs.All()[0].ContextMap(), log.DefaultMessageKey)
+}
+
+func TestSamplingConfig_getTick(t *testing.T) {
+	require.Equal(t, time.Second, (&SamplingConfig{}).getTick())
+	require.Equal(t, time.Minute, (&SamplingConfig{Tick: time.Minute}).getTick())
+}
+
+func TestNewSamplingConfig(t *testing.T) {
+	c, err := NewSamplingConfig(time.Minute, map[string]SamplingRule{
+		"debug": {Initial: 1, Thereafter: 0},
+		"WARN":  {Initial: 5, Thereafter: 10},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &SamplingConfig{
+		Tick: time.Minute,
+		Rules: map[zapcore.Level]SamplingRule{
+			zapcore.DebugLevel: {Initial: 1, Thereafter: 0},
+			zapcore.WarnLevel:  {Initial: 5, Thereafter: 10},
+		},
+	}, c)
+
+	_, err = NewSamplingConfig(0, map[string]SamplingRule{"verbose": {Initial: 1}})
+	require.Error(t, err)
+	_, err = NewSamplingConfig(0, map[string]SamplingRule{"info": {Initial: -1}})
+	require.Error(t, err)
+}
@@ -35,28 +35,38 @@ func (l *ZapLogger) Log(level log.Level, keyvals ...interface{}) error {
 		l.log.Warn(fmt.Sprint("Keyvalues must appear in pairs: ", keyvals))
 		return nil
 	}
-	// Zap.Field is used when keyvals pairs appear
-	var data []zap.Field
+	// Zap.Field is used when keyvals pairs appear.
+	// The kratos message key becomes the zap message so that sampling can tell entries apart.
+	var (
+		msg  string
+		data []zap.Field
+	)
 	for i := 0; i < len(keyvals); i += 2 {
-		data = append(data, zap.Any(fmt.Sprint(keyvals[i]), fmt.Sprint(keyvals[i+1])))
+		key := fmt.Sprint(keyvals[i])
+		if key == log.DefaultMessageKey {
+			msg = fmt.Sprint(keyvals[i+1])
+			continue
+		}
+		data = append(data, zap.Any(key, fmt.Sprint(keyvals[i+1])))
 	}
 	switch level {
 	case log.LevelDebug:
-		l.log.Debug("", data...)
+		l.log.Debug(msg, data...)
 	case log.LevelInfo:
-		l.log.Info("", data...)
+		l.log.Info(msg, data...)
 	case log.LevelWarn:
-		l.log.Warn("", data...)
+		l.log.Warn(msg, data...)
 	case log.LevelError:
-		l.log.Error("", data...)
+		l.log.Error(msg, data...)
 	case log.LevelFatal:
-		l.log.Fatal("", data...)
+		l.log.Fatal(msg, data...)
 	}
 	return nil
 }
 
 // InitDefaultLogger creates a console logger.
-func InitDefaultLogger(lvl zapcore.Level) *ZapLogger {
+// Extra options such as WithSampling are applied after the defaults.
+func InitDefaultLogger(lvl zapcore.Level, opts ...zap.Option) *ZapLogger {
 	eConfig := zapcore.EncoderConfig{
 		TimeKey:        "t",
 		LevelKey:       "level",
@@ -74,14 +84,17 @@ func InitDefaultLogger(lvl zapcore.Level) *ZapLogger {
 	return NewZapLogger(
 		zapcore.NewConsoleEncoder(eConfig),
 		zap.NewAtomicLevelAt(lvl),
-		zap.AddStacktrace(zap.NewAtomicLevelAt(zapcore.ErrorLevel)),
-		zap.AddCaller(),
-		zap.AddCallerSkip(2),
+		append([]zap.Option{
+			zap.AddStacktrace(zap.NewAtomicLevelAt(zapcore.ErrorLevel)),
+			zap.AddCaller(),
+			zap.AddCallerSkip(2),
+		}, opts...)...,
 	)
 }
 
 // InitJSONLogger creates a JSON logger.
-func InitJSONLogger(lvl zapcore.Level) *ZapLogger {
+// Extra options such as WithSampling are applied after the defaults.
+func InitJSONLogger(lvl zapcore.Level, opts ...zap.Option) *ZapLogger {
 	eConfig := zap.NewProductionEncoderConfig()
 	eConfig.EncodeDuration = zapcore.SecondsDurationEncoder
 	eConfig.EncodeTime = timeEncoder
@@ -90,9 +103,11 @@ func InitJSONLogger(lvl zapcore.Level) *ZapLogger {
 	return NewZapLogger(
 		zapcore.NewJSONEncoder(eConfig),
 		zap.NewAtomicLevelAt(lvl),
-		zap.AddStacktrace(zap.NewAtomicLevelAt(zapcore.ErrorLevel)),
-		zap.AddCaller(),
-		zap.AddCallerSkip(2),
+		append([]zap.Option{
+			zap.AddStacktrace(zap.NewAtomicLevelAt(zapcore.ErrorLevel)),
+			zap.AddCaller(),
+			zap.AddCallerSkip(2),
+		}, opts...)...,
 	)
 }
 
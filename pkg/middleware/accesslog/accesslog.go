@@ -0,0 +1,136 @@
+package accesslog
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kratos/kratos/v2/encoding"
+	"github.com/go-kratos/kratos/v2/encoding/json"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	zaplog "github.com/go-kratos/kratos-layout/pkg/log"
+)
+
+// DefaultMaxDumpSize is the default size in bytes of the longest request or
+// reply dump.
+const DefaultMaxDumpSize = 4096
+
+// Option is access log option.
+type Option func(*options)
+
+type options struct {
+	redactor    *zaplog.Redactor
+	dumpReply   bool
+	maxDumpSize int
+}
+
+// WithRedactor sets the redactor applied to request, reply and error dumps.
+func WithRedactor(r *zaplog.Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
+
+// WithDumpReply enables or disables dumping the reply body.
+func WithDumpReply(enabled bool) Option {
+	return func(o *options) {
+		o.dumpReply = enabled
+	}
+}
+
+// WithMaxDumpSize sets the size in bytes of the longest request or reply
+// dump; longer dumps are truncated. A size that is not positive disables
+// truncation.
+func WithMaxDumpSize(size int) Option {
+	return func(o *options) {
+		o.maxDumpSize = size
+	}
+}
+
+// Server returns a middleware that writes one access log line per request.
+// Request and reply bodies are dumped as JSON with sensitive fields redacted,
+// and truncated to the maximum dump size. As it runs before validation, the
+// dumps of oversized requests are truncated too.
+func Server(logger log.Logger, opts ...Option) middleware.Middleware {
+	o := &options{
+		redactor:    zaplog.NewRedactor(),
+		dumpReply:   true,
+		maxDumpSize: DefaultMaxDumpSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	codec := encoding.GetCodec(json.Name)
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (reply any, err error) {
+			var kind, operation string
+			if info, ok := transport.FromServerContext(ctx); ok {
+				kind = info.Kind().String()
+				operation = info.Operation()
+			}
+
+			startTime := time.Now()
+			reply, err = handler(ctx, req)
+
+			keyvals := []any{
+				"kind", "server",
+				"component", kind,
+				"operation", operation,
+				"request", o.dump(codec, req),
+			}
+			if o.dumpReply && err == nil {
+				keyvals = append(keyvals, "reply", o.dump(codec, reply))
+			}
+
+			level := log.LevelInfo
+			code := int32(200)
+			if se := errors.FromError(err); se != nil {
+				level = log.LevelError
+				code = se.Code
+				keyvals = append(keyvals,
+					"reason", se.Reason,
+					"error", o.redactor.RedactString(se.Message),
+					"metadata", o.redactor.RedactMap(se.Metadata),
+				)
+			}
+			keyvals = append(keyvals,
+				"code", code,
+				"latency", time.Since(startTime).Seconds(),
+			)
+
+			_ = log.WithContext(ctx, logger).Log(level, keyvals...)
+			return reply, err
+		}
+	}
+}
+
+// dump encodes v as JSON, redacts it and truncates it.
+func (o *options) dump(codec encoding.Codec, v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return o.truncate(o.redactor.RedactString(fmt.Sprintf("%+v", v)))
+	}
+	return o.truncate(string(o.redactor.RedactJSON(data)))
+}
+
+// truncate cuts s to the maximum dump size, at a rune boundary, and notes
+// how many bytes were cut.
+func (o *options) truncate(s string) string {
+	if o.maxDumpSize <= 0 || len(s) <= o.maxDumpSize {
+		return s
+	}
+	n := o.maxDumpSize
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return fmt.Sprintf("%s...(%d bytes truncated)", s[:n], len(s)-n)
+}
@@ -0,0 +1,120 @@
+package accesslog
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+type capturedLog struct {
+	level   log.Level
+	keyvals map[string]any
+}
+
+type captureLogger struct {
+	logs []capturedLog
+}
+
+func (l *captureLogger) Log(level log.Level, keyvals ...any) error {
+	m := make(map[string]any, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		m[keyvals[i].(string)] = keyvals[i+1]
+	}
+	l.logs = append(l.logs, capturedLog{level: level, keyvals: m})
+	return nil
+}
+
+type loginRequest struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+type loginReply struct {
+	Token string `json:"token"`
+	Email string `json:"email"`
+}
+
+func TestServer(t *testing.T) {
+	logger := &captureLogger{}
+	h := Server(logger)(func(context.Context, any) (any, error) {
+		return &loginReply{Token: "abc", Email: "bob@example.com"}, nil
+	})
+
+	_, err := h(context.Background(), &loginRequest{User: "bob", Password: "secret"})
+	require.NoError(t, err)
+
+	require.Len(t, logger.logs, 1)
+	entry := logger.logs[0]
+	require.Equal(t, log.LevelInfo, entry.level)
+	require.JSONEq(t, `{"user":"bob","password":"******"}`, entry.keyvals["request"].(string))
+	require.JSONEq(t, `{"token":"******","email":"******"}`, entry.keyvals["reply"].(string))
+}
+
+func TestServer_Error(t *testing.T) {
+	logger := &captureLogger{}
+	h := Server(logger, WithDumpReply(false))(func(context.Context, any) (any, error) {
+		return nil, errors.BadRequest("INVALID", "bad email bob@example.com").
+			WithMetadata(map[string]string{"authorization": "Bearer abc", "field": "email"})
+	})
+
+	_, err := h(context.Background(), &loginRequest{User: "bob"})
+	require.Error(t, err)
+
+	require.Len(t, logger.logs, 1)
+	entry := logger.logs[0]
+	require.Equal(t, log.LevelError, entry.level)
+	require.Equal(t, int32(400), entry.keyvals["code"])
+	require.Equal(t, "INVALID", entry.keyvals["reason"])
+	require.Equal(t, "bad email ******", entry.keyvals["error"])
+	require.Equal(t, map[string]string{"authorization": "******", "field": "email"}, entry.keyvals["metadata"])
+	require.NotContains(t, entry.keyvals, "reply")
+}
+
+func TestServer_MaxDumpSize(t *testing.T) {
+	logger := &captureLogger{}
+	h := Server(logger, WithMaxDumpSize(16))(func(context.Context, any) (any, error) {
+		return &loginReply{Token: "abc"}, nil
+	})
+
+	_, err := h(context.Background(), &loginRequest{User: strings.Repeat("b", 1<<20)})
+	require.NoError(t, err)
+
+	require.Len(t, logger.logs, 1)
+	entry := logger.logs[0]
+	requireTruncated(t, entry.keyvals["request"].(string), 16)
+	requireTruncated(t, entry.keyvals["reply"].(string), 16)
+}
+
+// requireTruncated checks that dump was truncated to size bytes.
+func requireTruncated(t *testing.T, dump string, size int) {
+	t.Helper()
+	kept, note, ok := strings.Cut(dump, "...(")
+	require.True(t, ok, dump)
+	require.Len(t, kept, size)
+	require.True(t, strings.HasSuffix(note, " bytes truncated)"), dump)
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		in   string
+		want string
+	}{
+		{name: "short", size: 8, in: "abc", want: "abc"},
+		{name: "exact", size: 3, in: "abc", want: "abc"},
+		{name: "long", size: 2, in: "abcd", want: "ab...(2 bytes truncated)"},
+		{name: "rune boundary", size: 2, in: "aé", want: "a...(2 bytes truncated)"},
+		{name: "disabled", size: 0, in: "abcd", want: "abcd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &options{maxDumpSize: tt.size}
+			require.Equal(t, tt.want, o.truncate(tt.in))
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/go-kratos/kratos-layout/pkg/principal"
+)
+
+const (
+	// HeaderKey is the HTTP header and gRPC metadata key carrying the idempotency key.
+	HeaderKey = "Idempotency-Key"
+	// ReplayedHeaderKey is set on replies replayed from a stored response.
+	ReplayedHeaderKey = "Idempotent-Replayed"
+	// MaxKeyLength is the longest accepted idempotency key.
+	MaxKeyLength = 255
+
+	// DefaultTTL is how long a completed response is replayed.
+	DefaultTTL = 24 * time.Hour
+	// DefaultLockTTL is how long an unfinished request blocks its key, so
+	// that a crashed request does not block it until DefaultTTL.
+	DefaultLockTTL = time.Minute
+)
+
+// Error reasons of the middleware.
+const (
+	ReasonInvalidKey  = "IDEMPOTENCY_KEY_INVALID"
+	ReasonInProgress  = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	ReasonKeyMismatch = "IDEMPOTENCY_KEY_MISMATCH"
+	ReasonUnavailable = "IDEMPOTENCY_UNAVAILABLE"
+	ReasonCorrupt     = "IDEMPOTENCY_RECORD_CORRUPT"
+)
+
+// ErrNotReserved is returned by Store.Save and Store.Delete when the key no
+// longer holds the reservation of the request, because it expired.
+var ErrNotReserved = errors.New("idempotency key is not reserved by the request")
+
+// Record is the state of an idempotency key kept in a Store.
+type Record struct {
+	// Fingerprint identifies the request that reserved the key.
+	Fingerprint string `json:"fingerprint"`
+	// Owner is a random token of the request that reserved the key, telling
+	// apart requests with the same fingerprint.
+	Owner string `json:"owner,omitempty"`
+	// Done is false while the request is in progress.
+	Done bool `json:"done"`
+	// Reply is the response of a done request, marshaled as an anypb.Any.
+	Reply []byte `json:"reply,omitempty"`
+}
+
+// Store keeps the records of idempotency keys.
+type Store interface {
+	// Reserve stores rec under key for ttl unless key already has a record,
+	// which is returned instead. It returns nil when rec was stored.
+	Reserve(ctx context.Context, key string, rec *Record, ttl time.Duration) (*Record, error)
+	// Save replaces the record reserved under key by rec, if key still holds
+	// reserved. It returns ErrNotReserved otherwise.
+	Save(ctx context.Context, key string, reserved, rec *Record, ttl time.Duration) error
+	// Delete removes the record reserved under key, if key still holds
+	// reserved. It returns ErrNotReserved otherwise.
+	Delete(ctx context.Context, key string, reserved *Record) error
+}
+
+// Option is idempotency option.
+type Option func(*options)
+
+type options struct {
+	operations map[string]struct{}
+	ttl        time.Duration
+	lockTTL    time.Duration
+	logger     log.Logger
+}
+
+// WithOperations sets the operations the middleware applies to. It applies
+// to none by default.
+func WithOperations(operations ...string) Option {
+	return func(o *options) {
+		for _, op := range operations {
+			o.operations[op] = struct{}{}
+		}
+	}
+}
+
+// WithTTL sets how long a completed response is replayed.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithLockTTL sets how long an unfinished request blocks its key.
+func WithLockTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.lockTTL = ttl
+	}
+}
+
+// WithLogger sets the logger of store failures that do not fail the request.
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// Server returns a middleware that makes the configured operations
+// idempotent for requests carrying an idempotency key. The first request
+// with a key runs the handler and stores its response; later requests with
+// the same key and operation get the stored response back, or a Conflict
+// error while the first one is still running. Reusing a key for a different
+// request fails with 422. Failed requests release their key, so they can be
+// retried. Keys are scoped to the operation and the principal of the
+// request, see principal.Server. Requests without a key are not affected.
+//
+// A request only completes or releases its own reservation: once it ran
+// longer than the lock TTL, another request may have reserved the key.
+func Server(store Store, opts ...Option) middleware.Middleware {
+	o := &options{
+		operations: make(map[string]struct{}),
+		ttl:        DefaultTTL,
+		lockTTL:    DefaultLockTTL,
+		logger:     log.GetLogger(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	helper := log.NewHelper(o.logger)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			if _, ok := o.operations[tr.Operation()]; !ok {
+				return handler(ctx, req)
+			}
+			key := tr.RequestHeader().Get(HeaderKey)
+			if key == "" {
+				return handler(ctx, req)
+			}
+			if len(key) > MaxKeyLength {
+				return nil, kerrors.BadRequest(ReasonInvalidKey, "idempotency key is too long")
+			}
+
+			fingerprint, err := fingerprint(req)
+			if err != nil {
+				return nil, err
+			}
+			owner, err := newOwner()
+			if err != nil {
+				return nil, err
+			}
+			storeKey := storeKey(ctx, tr.Operation(), key)
+			reserved := &Record{Fingerprint: fingerprint, Owner: owner}
+			existing, err := store.Reserve(ctx, storeKey, reserved, o.lockTTL)
+			if err != nil {
+				return nil, kerrors.ServiceUnavailable(ReasonUnavailable, "idempotency store is unavailable").WithCause(err)
+			}
+			if existing != nil {
+				return replay(tr, existing, fingerprint)
+			}
+
+			reply, err := handler(ctx, req)
+			if err != nil {
+				if delErr := store.Delete(context.WithoutCancel(ctx), storeKey, reserved); delErr != nil {
+					helper.WithContext(ctx).Errorf("release idempotency key %s failed: %v", storeKey, delErr)
+				}
+				return nil, err
+			}
+			if err := save(ctx, store, storeKey, reserved, reply, o.ttl); err != nil {
+				// The operation succeeded; a retry gets a conflict until the
+				// lock expires, or runs again if it already did.
+				helper.WithContext(ctx).Errorf("save idempotent response %s failed: %v", storeKey, err)
+			}
+			return reply, nil
+		}
+	}
+}
+
+func replay(tr transport.Transporter, rec *Record, fingerprint string) (any, error) {
+	if rec.Fingerprint != fingerprint {
+		return nil, kerrors.New(422, ReasonKeyMismatch, "idempotency key was used for a different request")
+	}
+	if !rec.Done {
+		return nil, kerrors.Conflict(ReasonInProgress, "a request with this idempotency key is in progress")
+	}
+	var reply anypb.Any
+	if err := proto.Unmarshal(rec.Reply, &reply); err != nil {
+		return nil, kerrors.InternalServer(ReasonCorrupt, "stored response is corrupt").WithCause(err)
+	}
+	msg, err := reply.UnmarshalNew()
+	if err != nil {
+		return nil, kerrors.InternalServer(ReasonCorrupt, "stored response is corrupt").WithCause(err)
+	}
+	tr.ReplyHeader().Set(ReplayedHeaderKey, "true")
+	return msg, nil
+}
+
+// storeKey returns the key of the record of an idempotency key. The
+// principal is escaped, so that its segment has no colon, and empty for
+// anonymous requests.
+func storeKey(ctx context.Context, operation, key string) string {
+	id, _ := principal.FromContext(ctx)
+	return operation + ":" + url.QueryEscape(id) + ":" + key
+}
+
+func newOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate idempotency owner failed: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func save(ctx context.Context, store Store, key string, reserved *Record, reply any, ttl time.Duration) error {
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return fmt.Errorf("reply %T is not a protobuf message", reply)
+	}
+	packed, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+	b, err := proto.Marshal(packed)
+	if err != nil {
+		return err
+	}
+	rec := &Record{Fingerprint: reserved.Fingerprint, Owner: reserved.Owner, Done: true, Reply: b}
+	return store.Save(context.WithoutCancel(ctx), key, reserved, rec, ttl)
+}
+
+// fingerprint hashes req, so that a key reused for another request is detected.
+func fingerprint(req any) (string, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if msg, ok := req.(proto.Message); ok {
+		b, err = proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	} else {
+		b, err = json.Marshal(req)
+	}
+	if err != nil {
+		return "", fmt.Errorf("fingerprint request failed: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
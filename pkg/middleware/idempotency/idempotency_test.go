@@ -0,0 +1,268 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/go-kratos/kratos-layout/pkg/principal"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type testTransport struct {
+	operation   string
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return tr.operation }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.reqHeader }
+func (tr *testTransport) ReplyHeader() transport.Header   { return tr.replyHeader }
+
+// memoryStore is a Store in memory; TTLs are ignored.
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+	err     error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]Record)}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, key string, rec *Record, _ time.Duration) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	if existing, ok := s.records[key]; ok {
+		return &existing, nil
+	}
+	s.records[key] = *rec
+	return nil, nil
+}
+
+func (s *memoryStore) Save(_ context.Context, key string, reserved, rec *Record, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.holds(key, reserved) {
+		return ErrNotReserved
+	}
+	s.records[key] = *rec
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string, reserved *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.holds(key, reserved) {
+		return ErrNotReserved
+	}
+	delete(s.records, key)
+	return nil
+}
+
+// holds reports whether key still holds the reservation reserved.
+func (s *memoryStore) holds(key string, reserved *Record) bool {
+	rec, ok := s.records[key]
+	return ok && !rec.Done && rec.Fingerprint == reserved.Fingerprint && rec.Owner == reserved.Owner
+}
+
+const testOperation = "/helloworld.v1.Greeter/CreateGreeter"
+
+// call runs h like a request to operation with the idempotency key.
+func call(h func(context.Context, any) (any, error), operation, key string, req any) (*testTransport, any, error) {
+	return callAs(context.Background(), h, operation, key, req)
+}
+
+// callAs runs h like call, with the principal of ctx.
+func callAs(ctx context.Context, h func(context.Context, any) (any, error), operation, key string, req any) (*testTransport, any, error) {
+	tr := &testTransport{operation: operation, reqHeader: headerCarrier{}, replyHeader: headerCarrier{}}
+	if key != "" {
+		tr.reqHeader.Set(HeaderKey, key)
+	}
+	reply, err := h(transport.NewServerContext(ctx, tr), req)
+	return tr, reply, err
+}
+
+func TestServer_Replay(t *testing.T) {
+	store := newMemoryStore()
+	calls := 0
+	h := Server(store, WithOperations(testOperation))(func(_ context.Context, req any) (any, error) {
+		calls++
+		return wrapperspb.String(req.(*wrapperspb.StringValue).Value + "!"), nil
+	})
+
+	tr, reply, err := call(h, testOperation, "k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	require.Equal(t, "hello!", reply.(*wrapperspb.StringValue).Value)
+	require.Empty(t, tr.replyHeader.Get(ReplayedHeaderKey))
+
+	tr, reply, err = call(h, testOperation, "k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	require.True(t, proto.Equal(wrapperspb.String("hello!"), reply.(proto.Message)))
+	require.Equal(t, "true", tr.replyHeader.Get(ReplayedHeaderKey))
+	require.Equal(t, 1, calls)
+
+	// The same key with another request is rejected.
+	_, _, err = call(h, testOperation, "k1", wrapperspb.String("other"))
+	require.Equal(t, int32(422), kerrors.FromError(err).Code)
+	require.Equal(t, ReasonKeyMismatch, kerrors.FromError(err).Reason)
+
+	// Keys are scoped to the operation, and requests without one always run.
+	_, _, err = call(h, testOperation, "k2", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	_, _, err = call(h, testOperation, "", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	_, _, err = call(h, "/helloworld.v1.Greeter/GetGreeter", "k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	require.Equal(t, 4, calls)
+}
+
+func TestServer_InProgress(t *testing.T) {
+	store := newMemoryStore()
+	started, release := make(chan struct{}), make(chan struct{})
+	h := Server(store, WithOperations(testOperation))(func(context.Context, any) (any, error) {
+		close(started)
+		<-release
+		return wrapperspb.String("done"), nil
+	})
+
+	done := make(chan error)
+	go func() {
+		_, _, err := call(h, testOperation, "k1", wrapperspb.String("hello"))
+		done <- err
+	}()
+	<-started
+
+	_, _, err := call(h, testOperation, "k1", wrapperspb.String("hello"))
+	require.True(t, kerrors.IsConflict(err))
+	require.Equal(t, ReasonInProgress, kerrors.FromError(err).Reason)
+
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestServer_FailureReleasesKey(t *testing.T) {
+	store := newMemoryStore()
+	fail := true
+	h := Server(store, WithOperations(testOperation))(func(context.Context, any) (any, error) {
+		if fail {
+			return nil, kerrors.ServiceUnavailable("DOWN", "try again")
+		}
+		return wrapperspb.String("ok"), nil
+	})
+
+	_, _, err := call(h, testOperation, "k1", wrapperspb.String("hello"))
+	require.True(t, kerrors.IsServiceUnavailable(err))
+	require.Empty(t, store.records)
+
+	fail = false
+	_, reply, err := call(h, testOperation, "k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	require.Equal(t, "ok", reply.(*wrapperspb.StringValue).Value)
+}
+
+func TestServer_Principal(t *testing.T) {
+	store := newMemoryStore()
+	calls := 0
+	h := Server(store, WithOperations(testOperation))(func(_ context.Context, req any) (any, error) {
+		calls++
+		return wrapperspb.String(req.(*wrapperspb.StringValue).Value + "!"), nil
+	})
+
+	// Keys are scoped to the principal, so callers neither collide nor see
+	// each other's responses.
+	alice := principal.NewContext(context.Background(), "alice")
+	_, _, err := callAs(alice, h, testOperation, "k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	tr, _, err := callAs(principal.NewContext(context.Background(), "bob"), h, testOperation, "k1", wrapperspb.String("other"))
+	require.NoError(t, err)
+	require.Empty(t, tr.replyHeader.Get(ReplayedHeaderKey))
+	_, _, err = call(h, testOperation, "k1", wrapperspb.String("anonymous"))
+	require.NoError(t, err)
+	// An anonymous key cannot address the record of a principal.
+	_, _, err = call(h, testOperation, "alice:k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	require.Equal(t, 4, calls)
+
+	tr, _, err = callAs(alice, h, testOperation, "k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	require.Equal(t, "true", tr.replyHeader.Get(ReplayedHeaderKey))
+	require.Equal(t, 4, calls)
+}
+
+func TestServer_ExpiredReservation(t *testing.T) {
+	store := newMemoryStore()
+	fail := false
+	// While the handler runs, its reservation expires, and a retry reserves
+	// the key again.
+	retry := Record{Fingerprint: "", Owner: "retry"}
+	h := Server(store, WithOperations(testOperation))(func(context.Context, any) (any, error) {
+		store.mu.Lock()
+		for key, rec := range store.records {
+			retry.Fingerprint = rec.Fingerprint
+			store.records[key] = retry
+		}
+		store.mu.Unlock()
+		if fail {
+			return nil, kerrors.ServiceUnavailable("DOWN", "try again")
+		}
+		return wrapperspb.String("ok"), nil
+	})
+
+	// The slow request neither completes the retry's reservation...
+	_, _, err := call(h, testOperation, "k1", wrapperspb.String("hello"))
+	require.NoError(t, err)
+	require.Len(t, store.records, 1)
+	for _, rec := range store.records {
+		require.Equal(t, retry, rec)
+	}
+
+	// ...nor releases it when it fails.
+	fail = true
+	_, _, err = call(h, testOperation, "k2", wrapperspb.String("hello"))
+	require.True(t, kerrors.IsServiceUnavailable(err))
+	require.Len(t, store.records, 2)
+}
+
+func TestServer_Errors(t *testing.T) {
+	store := newMemoryStore()
+	h := Server(store, WithOperations(testOperation))(func(context.Context, any) (any, error) {
+		return wrapperspb.String("ok"), nil
+	})
+
+	_, _, err := call(h, testOperation, strings.Repeat("k", MaxKeyLength+1), wrapperspb.String("hello"))
+	require.True(t, kerrors.IsBadRequest(err))
+
+	store.err = errors.New("connection refused")
+	_, _, err = call(h, testOperation, "k1", wrapperspb.String("hello"))
+	require.True(t, kerrors.IsServiceUnavailable(err))
+	require.Equal(t, ReasonUnavailable, kerrors.FromError(err).Reason)
+}
@@ -0,0 +1,93 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/google/uuid"
+)
+
+// HeaderKey is the HTTP header and gRPC metadata key carrying the request ID.
+const HeaderKey = "X-Request-ID"
+
+// MaxLength is the length of the longest incoming request ID that is
+// propagated.
+const MaxLength = 128
+
+type requestIDKey struct{}
+
+// Option is request ID option.
+type Option func(*options)
+
+type options struct {
+	generator func() string
+}
+
+// WithGenerator sets the function used to generate missing request IDs.
+func WithGenerator(generator func() string) Option {
+	return func(o *options) {
+		o.generator = generator
+	}
+}
+
+// Server returns a middleware that propagates the incoming request ID,
+// or generates one when the request has none. The ID is stored in the
+// context and echoed in the reply header. As it ends up in logs and
+// events, an incoming ID is only propagated when it is at most MaxLength
+// long and consists of ASCII letters, digits and "-_.:"; otherwise a new
+// one is generated.
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{generator: uuid.NewString}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				id := tr.RequestHeader().Get(HeaderKey)
+				if !valid(id) {
+					id = o.generator()
+				}
+				tr.ReplyHeader().Set(HeaderKey, id)
+				ctx = NewContext(ctx, id)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// valid reports whether id is safe to propagate.
+func valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+	for _, c := range []byte(id) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a new context that carries the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, if any.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Valuer returns a log valuer that extracts the request ID from the context.
+func Valuer() log.Valuer {
+	return func(ctx context.Context) any {
+		return FromContext(ctx)
+	}
+}
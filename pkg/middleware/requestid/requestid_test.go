@@ -0,0 +1,84 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type testTransport struct {
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return "/test" }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.reqHeader }
+func (tr *testTransport) ReplyHeader() transport.Header   { return tr.replyHeader }
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		expected string
+	}{
+		{name: "propagate", incoming: "req-1", expected: "req-1"},
+		{name: "generate", incoming: "", expected: "generated"},
+		{name: "uuid", incoming: "4b1c2d3e-0f1a-4b2c-8d3e-4f5a6b7c8d9e", expected: "4b1c2d3e-0f1a-4b2c-8d3e-4f5a6b7c8d9e"},
+		{name: "max length", incoming: strings.Repeat("a", MaxLength), expected: strings.Repeat("a", MaxLength)},
+		{name: "too long", incoming: strings.Repeat("a", MaxLength+1), expected: "generated"},
+		{name: "space", incoming: "req 1", expected: "generated"},
+		{name: "log injection", incoming: "req-1\nlevel=ERROR", expected: "generated"},
+		{name: "non-ascii", incoming: "req-é", expected: "generated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &testTransport{reqHeader: headerCarrier{}, replyHeader: headerCarrier{}}
+			if tt.incoming != "" {
+				tr.reqHeader.Set(HeaderKey, tt.incoming)
+			}
+			ctx := transport.NewServerContext(context.Background(), tr)
+
+			var got, logged string
+			h := Server(WithGenerator(func() string { return "generated" }))(func(ctx context.Context, _ any) (any, error) {
+				got = FromContext(ctx)
+				logged, _ = Valuer()(ctx).(string)
+				return nil, nil
+			})
+			_, err := h(ctx, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+			require.Equal(t, tt.expected, logged)
+			require.Equal(t, tt.expected, tr.replyHeader.Get(HeaderKey))
+		})
+	}
+}
+
+func TestServer_NoTransport(t *testing.T) {
+	h := Server()(func(ctx context.Context, _ any) (any, error) {
+		return FromContext(ctx), nil
+	})
+	reply, err := h(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, reply)
+}
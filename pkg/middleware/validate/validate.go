@@ -0,0 +1,185 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultReason is the error reason of validation failures, matching the
+// deprecated kratos validate middleware.
+const DefaultReason = "VALIDATOR"
+
+// validatorAll is implemented by messages generated by protoc-gen-validate.
+type validatorAll interface {
+	ValidateAll() error
+}
+
+type validator interface {
+	Validate() error
+}
+
+// multiError is the error ValidateAll returns for several violations.
+type multiError interface {
+	AllErrors() []error
+}
+
+// fieldError is a violation of one field; Cause holds the violations of a nested message.
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// Option is validate option.
+type Option func(*options)
+
+type options struct {
+	reason string
+}
+
+// WithReason sets the error reason of validation failures.
+func WithReason(reason string) Option {
+	return func(o *options) {
+		o.reason = reason
+	}
+}
+
+// Server returns a middleware that validates requests generated with
+// protoc-gen-validate rules. A request violating them fails with a
+// BadRequest error whose metadata maps each violated field path, using the
+// proto field names, to its reason.
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{reason: DefaultReason}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			if err := validate(req); err != nil {
+				violations := FieldViolations(err)
+				if m, ok := req.(proto.Message); ok {
+					violations = protoFieldPaths(m.ProtoReflect().Descriptor(), violations)
+				}
+				return nil, kerrors.BadRequest(o.reason, message(violations, err)).
+					WithMetadata(violations).
+					WithCause(err)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+func validate(req any) error {
+	switch v := req.(type) {
+	case validatorAll:
+		return v.ValidateAll()
+	case validator:
+		return v.Validate()
+	default:
+		return nil
+	}
+}
+
+// FieldViolations maps the field paths of the violations in a validation
+// error to their reasons. Nested fields are joined with ".".
+func FieldViolations(err error) map[string]string {
+	violations := make(map[string]string)
+	collectViolations(err, "", violations)
+	return violations
+}
+
+func collectViolations(err error, prefix string, violations map[string]string) {
+	var multi multiError
+	if errors.As(err, &multi) {
+		for _, e := range multi.AllErrors() {
+			collectViolations(e, prefix, violations)
+		}
+		return
+	}
+
+	var field fieldError
+	if !errors.As(err, &field) {
+		return
+	}
+	path := prefix + field.Field()
+	// A nested message reports its own violations as the cause.
+	if cause := field.Cause(); cause != nil && (errors.As(cause, &multi) || errors.As(cause, new(fieldError))) {
+		collectViolations(cause, path+".", violations)
+		return
+	}
+	violations[path] = field.Reason()
+}
+
+// protoFieldPaths renames the Go field paths reported by protoc-gen-validate
+// to the proto field names of md, which clients know.
+func protoFieldPaths(md protoreflect.MessageDescriptor, violations map[string]string) map[string]string {
+	renamed := make(map[string]string, len(violations))
+	for path, reason := range violations {
+		renamed[protoFieldPath(md, path)] = reason
+	}
+	return renamed
+}
+
+func protoFieldPath(md protoreflect.MessageDescriptor, path string) string {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		if md == nil {
+			break
+		}
+		fd := findGoField(md, segment)
+		if fd == nil {
+			break
+		}
+		segments[i] = string(fd.Name())
+		md = fd.Message()
+	}
+	return strings.Join(segments, ".")
+}
+
+func findGoField(md protoreflect.MessageDescriptor, goName string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	for i := range fields.Len() {
+		if fd := fields.Get(i); goCamelCase(string(fd.Name())) == goName {
+			return fd
+		}
+	}
+	return nil
+}
+
+// goCamelCase converts a proto field name to its generated Go name, like
+// protoc-gen-go does for the usual snake_case names.
+func goCamelCase(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = r >= '0' && r <= '9'
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func message(violations map[string]string, err error) string {
+	if len(violations) == 0 {
+		return err.Error()
+	}
+	parts := make([]string, 0, len(violations))
+	for field, reason := range violations {
+		parts = append(parts, field+": "+reason)
+	}
+	slices.Sort(parts)
+	return "invalid request: " + strings.Join(parts, "; ")
+}
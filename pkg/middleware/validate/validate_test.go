@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fieldErr and multiErr mimic the errors generated by protoc-gen-validate.
+type fieldErr struct {
+	field  string
+	reason string
+	cause  error
+}
+
+func (e fieldErr) Field() string  { return e.field }
+func (e fieldErr) Reason() string { return e.reason }
+func (e fieldErr) Cause() error   { return e.cause }
+func (e fieldErr) Error() string  { return "invalid " + e.field + ": " + e.reason }
+
+type multiErr []error
+
+func (m multiErr) AllErrors() []error { return m }
+func (m multiErr) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, e := range m {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+type validatedRequest struct {
+	err error
+}
+
+func (r validatedRequest) ValidateAll() error { return r.err }
+
+type legacyRequest struct {
+	err error
+}
+
+func (r legacyRequest) Validate() error { return r.err }
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            any
+		opts           []Option
+		wantReason     string
+		wantViolations map[string]string
+	}{
+		{
+			name: "valid request",
+			req:  validatedRequest{},
+		},
+		{
+			name: "request without rules",
+			req:  struct{}{},
+		},
+		{
+			name:           "single violation",
+			req:            legacyRequest{err: fieldErr{field: "Name", reason: "value length must be at least 1 runes"}},
+			wantReason:     DefaultReason,
+			wantViolations: map[string]string{"Name": "value length must be at least 1 runes"},
+		},
+		{
+			name: "all violations with nested fields",
+			req: validatedRequest{err: multiErr{
+				fieldErr{field: "Id", reason: "value must be greater than 0"},
+				fieldErr{field: "Greeter", reason: "embedded message failed validation", cause: multiErr{
+					fieldErr{field: "Hello", reason: "value length must be at most 255 runes"},
+				}},
+			}},
+			opts:       []Option{WithReason("INVALID_ARGUMENT")},
+			wantReason: "INVALID_ARGUMENT",
+			wantViolations: map[string]string{
+				"Id":            "value must be greater than 0",
+				"Greeter.Hello": "value length must be at most 255 runes",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := Server(tt.opts...)(func(ctx context.Context, req any) (any, error) {
+				called = true
+				return "reply", nil
+			})
+
+			reply, err := h(context.Background(), tt.req)
+			if tt.wantViolations == nil {
+				require.NoError(t, err)
+				require.Equal(t, "reply", reply)
+				require.True(t, called)
+				return
+			}
+
+			require.False(t, called)
+			se := kerrors.FromError(err)
+			require.Equal(t, int32(400), se.Code)
+			require.Equal(t, tt.wantReason, se.Reason)
+			require.Equal(t, tt.wantViolations, se.Metadata)
+			for field := range tt.wantViolations {
+				require.Contains(t, se.Message, field)
+			}
+		})
+	}
+}
+
+func TestFieldViolations_OpaqueError(t *testing.T) {
+	require.Empty(t, FieldViolations(errors.New("boom")))
+}
+
+func TestProtoFieldPaths(t *testing.T) {
+	md := (&descriptorpb.FieldDescriptorProto{}).ProtoReflect().Descriptor()
+	got := protoFieldPaths(md, map[string]string{
+		"TypeName":               "required",
+		"Options.UnverifiedLazy": "must be false",
+		"Unknown.Field":          "kept as is",
+	})
+	require.Equal(t, map[string]string{
+		"type_name":               "required",
+		"options.unverified_lazy": "must be false",
+		"Unknown.Field":           "kept as is",
+	}, got)
+}
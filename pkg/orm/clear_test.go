@@ -0,0 +1,157 @@
+package orm
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// clearTestConfigs returns a MySQL and a SQLite config for ClearAllData tests.
+func clearTestConfigs(tb testing.TB) map[string]*DBConfig {
+	return map[string]*DBConfig{
+		DriverMySQL: {
+			Username:     "root",
+			Password:     password,
+			Host:         "127.0.0.1",
+			Port:         "3306",
+			DBName:       "clear_test",
+			MaxIdleConns: 10,
+			MaxOpenConns: 100,
+			DBCharset:    "utf8mb4",
+		},
+		DriverSQLite: {
+			Driver:       DriverSQLite,
+			DBName:       filepath.Join(tb.TempDir(), "clear_test.db"),
+			MaxIdleConns: 10,
+			MaxOpenConns: 100,
+		},
+	}
+}
+
+// newClearTestDB creates a database with parent and child tables linked by a
+// foreign key, a view, and a migration table.
+func newClearTestDB(tb testing.TB, dbConf *DBConfig) DB {
+	tb.Helper()
+
+	utilDB, err := MakeDBUtil(dbConf)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { utilDB.Close() })
+	require.NoError(tb, utilDB.DropDB())
+	require.NoError(tb, utilDB.CreateDB())
+	tb.Cleanup(func() { require.NoError(tb, utilDB.DropDB()) })
+
+	db, err := MakeDB(dbConf)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { db.Close() })
+
+	autoIncrement := "BIGINT NOT NULL AUTO_INCREMENT"
+	if dbConf.Driver == DriverSQLite {
+		autoIncrement = "INTEGER NOT NULL"
+	}
+	for _, stmt := range []string{
+		fmt.Sprintf("CREATE TABLE parents (id %s, PRIMARY KEY (id))", autoIncrement),
+		fmt.Sprintf("CREATE TABLE children (id %s, parent_id BIGINT NOT NULL, PRIMARY KEY (id), "+
+			"FOREIGN KEY (parent_id) REFERENCES parents (id))", autoIncrement),
+		"CREATE VIEW parent_ids AS SELECT id FROM parents",
+		"CREATE TABLE schema_migrations (version BIGINT NOT NULL, PRIMARY KEY (version))",
+	} {
+		require.NoError(tb, db.GetDB().Exec(stmt).Error)
+	}
+	return db
+}
+
+func seedClearTestDB(tb testing.TB, db DB, rows int) {
+	tb.Helper()
+	gdb := db.GetDB()
+	require.NoError(tb, gdb.Exec("INSERT INTO schema_migrations (version) VALUES (1)").Error)
+	for i := 0; i < rows; i++ {
+		require.NoError(tb, gdb.Exec("INSERT INTO parents (id) VALUES (NULL)").Error)
+	}
+	require.NoError(tb, gdb.Exec("INSERT INTO children (parent_id) SELECT id FROM parents").Error)
+}
+
+func countRows(tb testing.TB, db DB, table string) int64 {
+	tb.Helper()
+	var count int64
+	require.NoError(tb, db.GetDB().Table(table).Count(&count).Error)
+	return count
+}
+
+func TestClearAllData_Truncate(t *testing.T) {
+	for name, dbConf := range clearTestConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			db := newClearTestDB(t, dbConf)
+			seedClearTestDB(t, db, 3)
+
+			require.NoError(t, db.ClearAllData(WithTruncate(), WithExcludeTables(DefaultMigrationTable)))
+			require.Zero(t, countRows(t, db, "parents"))
+			require.Zero(t, countRows(t, db, "children"))
+			require.Equal(t, int64(1), countRows(t, db, DefaultMigrationTable))
+
+			// Auto-increment counters restart.
+			require.NoError(t, db.GetDB().Exec("INSERT INTO parents (id) VALUES (NULL)").Error)
+			var id int64
+			require.NoError(t, db.GetDB().Raw("SELECT id FROM parents").Scan(&id).Error)
+			require.Equal(t, int64(1), id)
+		})
+	}
+}
+
+func TestClearAllData_Delete(t *testing.T) {
+	for name, dbConf := range clearTestConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			db := newClearTestDB(t, dbConf)
+			seedClearTestDB(t, db, 3)
+
+			// DELETE follows table order, so clear the referencing table by
+			// hand first; the view is skipped rather than deleted from.
+			require.NoError(t, db.GetDB().Exec("DELETE FROM children").Error)
+			require.NoError(t, db.ClearAllData(WithExcludeTables(DefaultMigrationTable)))
+			require.Zero(t, countRows(t, db, "parents"))
+			require.Equal(t, int64(1), countRows(t, db, DefaultMigrationTable))
+		})
+	}
+}
+
+// BenchmarkClearAllData compares DELETE with TRUNCATE on 10 tables of 100 rows.
+// The tables have no foreign keys, so that DELETE can clear them in any order.
+func BenchmarkClearAllData(b *testing.B) {
+	const tables, rows = 10, 100
+	modes := []struct {
+		name string
+		opts []ClearOption
+	}{
+		{"delete", nil},
+		{"truncate", []ClearOption{WithTruncate()}},
+	}
+
+	for name, dbConf := range clearTestConfigs(b) {
+		for _, mode := range modes {
+			b.Run(name+"/"+mode.name, func(b *testing.B) {
+				db := newClearTestDB(b, dbConf)
+				gdb := db.GetDB()
+				require.NoError(b, gdb.Exec("DROP TABLE children").Error)
+				for t := 0; t < tables; t++ {
+					require.NoError(b, gdb.Exec(fmt.Sprintf("CREATE TABLE bench_%d (id BIGINT NOT NULL, PRIMARY KEY (id))", t)).Error)
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					for t := 0; t < tables; t++ {
+						values := make([]map[string]any, 0, rows)
+						for r := 1; r <= rows; r++ {
+							values = append(values, map[string]any{"id": r})
+						}
+						require.NoError(b, gdb.Table(fmt.Sprintf("bench_%d", t)).Create(values).Error)
+					}
+					b.StartTimer()
+
+					require.NoError(b, db.ClearAllData(mode.opts...))
+				}
+			})
+		}
+	}
+}
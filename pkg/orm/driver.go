@@ -0,0 +1,70 @@
+package orm
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Supported database drivers.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// driver holds the SQL dialect specific parts of a database.
+type driver interface {
+	// sqlDriverName is the database/sql driver name.
+	sqlDriverName() string
+	// buildDSN builds the DSN connecting to dbName on host.
+	buildDSN(c *DBConfig, h hostConfig, dbName string) string
+	// utilDBName is the database the util connection opens.
+	utilDBName(c *DBConfig) string
+	// dialector wraps an opened pool in a gorm dialector.
+	dialector(conn *sql.DB) gorm.Dialector
+	// replicaDialector wraps an opened replica pool, which may be unreachable, in a gorm dialector.
+	replicaDialector(conn *sql.DB) gorm.Dialector
+	// quoteIdentifier escapes a SQL identifier.
+	quoteIdentifier(name string) string
+	createDB(utilDB *gorm.DB, c *DBConfig) error
+	dropDB(utilDB *gorm.DB, c *DBConfig) error
+	// listTablesSQL returns the query listing the base tables of the current database.
+	listTablesSQL() string
+	// truncateTables empties tables and resets their auto-increment counters,
+	// ignoring foreign keys between them. conn is a single connection.
+	truncateTables(conn *gorm.DB, tables []string) error
+}
+
+// hostConfig is the address and credentials of one database server.
+type hostConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+}
+
+// getDriver returns the configured driver, defaulting to MySQL
+func (c *DBConfig) getDriver() (driver, error) {
+	switch c.Driver {
+	case "", DriverMySQL:
+		return mysqlDriver{}, nil
+	case DriverPostgres:
+		return postgresDriver{}, nil
+	case DriverSQLite:
+		return sqliteDriver{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", c.Driver)
+	}
+}
+
+// primaryHost returns the address and credentials of the primary
+func (c *DBConfig) primaryHost() hostConfig {
+	return hostConfig{
+		Host:     c.Host,
+		Port:     c.Port,
+		Username: c.Username,
+		Password: c.Password,
+	}
+}
@@ -0,0 +1,146 @@
+package orm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBConfig_getDriver(t *testing.T) {
+	tests := []struct {
+		driver   string
+		expected driver
+	}{
+		{"", mysqlDriver{}},
+		{DriverMySQL, mysqlDriver{}},
+		{DriverPostgres, postgresDriver{}},
+		{DriverSQLite, sqliteDriver{}},
+	}
+
+	for _, tt := range tests {
+		d, err := (&DBConfig{Driver: tt.driver}).getDriver()
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, d)
+	}
+
+	_, err := (&DBConfig{Driver: "oracle"}).getDriver()
+	require.Error(t, err)
+	_, err = MakeDB(&DBConfig{Driver: "oracle"})
+	require.Error(t, err)
+}
+
+func TestPostgresDriver_buildDSN(t *testing.T) {
+	gm := &gormDB{dbConfig: &DBConfig{
+		Driver:   DriverPostgres,
+		Username: "user",
+		Password: "p@ss word",
+		Host:     "localhost",
+		Port:     "5432",
+		DBName:   "testdb",
+	}}
+
+	require.Equal(t,
+		"host=localhost port=5432 user=user password='p@ss word' dbname=testdb sslmode=disable",
+		gm.buildDSN("testdb"))
+	require.Equal(t,
+		"host=localhost port=5432 user=user password='p@ss word' dbname=postgres sslmode=disable",
+		gm.buildDSN(postgresDriver{}.utilDBName(gm.dbConfig)))
+
+	gm.dbConfig.SSLMode = "require"
+	require.Contains(t, gm.buildDSN("testdb"), "sslmode=require")
+}
+
+func TestPgQuoteValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plain", "plain"},
+		{"", "''"},
+		{"with space", "'with space'"},
+		{`it's`, `'it\'s'`},
+		{`back\slash`, `'back\\slash'`},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, pgQuoteValue(tt.input))
+	}
+}
+
+func TestDriver_quoteIdentifier(t *testing.T) {
+	require.Equal(t, `"my""table"`, postgresDriver{}.quoteIdentifier(`my"table`))
+	require.Equal(t, `"my""table"`, sqliteDriver{}.quoteIdentifier(`my"table`))
+}
+
+func TestSQLiteDriver_buildDSN(t *testing.T) {
+	d := sqliteDriver{}
+	require.Equal(t, ":memory:?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)",
+		d.buildDSN(nil, hostConfig{}, SQLiteMemory))
+	require.Equal(t, "file:data.db?mode=rwc&_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)",
+		d.buildDSN(nil, hostConfig{}, "file:data.db?mode=rwc"))
+	require.Equal(t, "data.db", sqlitePath("file:data.db?mode=rwc"))
+}
+
+func TestSQLite_File(t *testing.T) {
+	dbConf := &DBConfig{
+		Driver:       DriverSQLite,
+		DBName:       filepath.Join(t.TempDir(), "nested", "orm_test.db"),
+		MaxIdleConns: 2,
+		MaxOpenConns: 4,
+	}
+
+	utilDB, err := MakeDBUtil(dbConf)
+	require.NoError(t, err)
+	defer utilDB.Close()
+
+	require.NoError(t, utilDB.CreateDB())
+	require.FileExists(t, dbConf.DBName)
+
+	db, err := MakeDB(dbConf)
+	require.NoError(t, err)
+
+	gdb := db.GetDB()
+	require.NoError(t, gdb.Exec(`CREATE TABLE "items" (id INTEGER PRIMARY KEY)`).Error)
+	require.NoError(t, gdb.Exec(`INSERT INTO "items" VALUES (1), (2)`).Error)
+	require.NoError(t, db.ClearAllData())
+
+	var count int64
+	require.NoError(t, gdb.Table("items").Count(&count).Error)
+	require.Equal(t, int64(0), count)
+	require.NoError(t, db.Close())
+
+	require.NoError(t, utilDB.DropDB())
+	require.NoFileExists(t, dbConf.DBName)
+}
+
+func TestSQLite_Memory(t *testing.T) {
+	dbConf := &DBConfig{Driver: DriverSQLite, DBName: SQLiteMemory}
+
+	db, err := MakeDB(dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+
+	gdb := db.GetDB()
+	require.NoError(t, gdb.Exec(`CREATE TABLE "items" (id INTEGER PRIMARY KEY)`).Error)
+	require.NoError(t, gdb.Exec(`INSERT INTO "items" VALUES (1)`).Error)
+
+	// The table outlives the connection that created it.
+	var count int64
+	require.NoError(t, gdb.Table("items").Count(&count).Error)
+	require.Equal(t, int64(1), count)
+
+	require.NoError(t, db.ClearAllData())
+	require.NoError(t, gdb.Table("items").Count(&count).Error)
+	require.Equal(t, int64(0), count)
+}
+
+func TestSQLite_Replicas(t *testing.T) {
+	_, err := MakeDB(&DBConfig{
+		Driver:   DriverSQLite,
+		DBName:   SQLiteMemory,
+		Replicas: []ReplicaConfig{{Host: "127.0.0.1", Port: "1"}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+}
@@ -0,0 +1,74 @@
+package orm
+
+import (
+	"errors"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// MySQL error numbers.
+const (
+	mysqlErrDupEntry        = 1062
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrLockDeadlock    = 1213
+)
+
+// PostgreSQL SQLSTATE codes.
+const (
+	pgUniqueViolation      = "23505"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// SQLite extended result codes.
+const (
+	sqliteBusy                  = 5
+	sqliteLocked                = 6
+	sqliteConstraintPrimaryKey  = 1555
+	sqliteConstraintUnique      = 2067
+	sqlitePrimaryResultCodeMask = 0xff
+)
+
+// IsDuplicateKey reports whether err is a unique or primary key violation of
+// any supported driver.
+func IsDuplicateKey(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDupEntry
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+	var sqliteErr *gosqlite.Error
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code()
+		return code == sqliteConstraintUnique || code == sqliteConstraintPrimaryKey
+	}
+	return false
+}
+
+// IsDeadlock reports whether err aborted a transaction because of a deadlock
+// or lock contention, so retrying the transaction may succeed.
+func IsDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrLockDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgDeadlockDetected || pgErr.Code == pgSerializationFailure
+	}
+	var sqliteErr *gosqlite.Error
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code() & sqlitePrimaryResultCodeMask
+		return code == sqliteBusy || code == sqliteLocked
+	}
+	return false
+}
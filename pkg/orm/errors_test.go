@@ -0,0 +1,65 @@
+package orm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestIsDuplicateKey(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "gorm", err: gorm.ErrDuplicatedKey, want: true},
+		{name: "mysql", err: &mysql.MySQLError{Number: mysqlErrDupEntry}, want: true},
+		{name: "mysql other", err: &mysql.MySQLError{Number: 1054}, want: false},
+		{name: "postgres wrapped", err: fmt.Errorf("insert failed: %w", &pgconn.PgError{Code: pgUniqueViolation}), want: true},
+		{name: "postgres other", err: &pgconn.PgError{Code: "23503"}, want: false},
+		{name: "opaque", err: errors.New("duplicate"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsDuplicateKey(tt.err))
+		})
+	}
+}
+
+func TestIsDuplicateKey_SQLite(t *testing.T) {
+	db := newFixtureTestDB(t)
+	gdb := db.GetDB()
+	require.NoError(t, gdb.Exec(`INSERT INTO users (id, name) VALUES (1, 'a')`).Error)
+
+	err := gdb.Exec(`INSERT INTO users (id, name) VALUES (1, 'b')`).Error
+	require.Error(t, err)
+	require.True(t, IsDuplicateKey(err))
+	require.False(t, IsDeadlock(err))
+}
+
+func TestIsDeadlock(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "mysql deadlock", err: &mysql.MySQLError{Number: mysqlErrLockDeadlock}, want: true},
+		{name: "mysql lock wait timeout", err: &mysql.MySQLError{Number: mysqlErrLockWaitTimeout}, want: true},
+		{name: "mysql duplicate", err: &mysql.MySQLError{Number: mysqlErrDupEntry}, want: false},
+		{name: "postgres deadlock", err: fmt.Errorf("commit failed: %w", &pgconn.PgError{Code: pgDeadlockDetected}), want: true},
+		{name: "postgres serialization", err: &pgconn.PgError{Code: pgSerializationFailure}, want: true},
+		{name: "not found", err: gorm.ErrRecordNotFound, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsDeadlock(tt.err))
+		})
+	}
+}
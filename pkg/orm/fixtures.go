@@ -0,0 +1,213 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Fixture files map table names to lists of rows, in YAML or JSON:
+//
+//	greeters:
+//	  - _label: alice
+//	    hello: Hello Alice
+//	greetings:
+//	  - greeter_id: {ref: greeters.alice}
+//	    text: hi
+//
+// A row with a _label can be referenced from any fixture row as
+// {ref: <table>.<label>}, which resolves to the id of the inserted row.
+// Tables are inserted in reference order, so referenced rows exist first.
+const (
+	fixtureLabelKey = "_label"
+	fixtureRefKey   = "ref"
+	fixtureIDColumn = "id"
+)
+
+type fixtureRow struct {
+	label  string
+	values map[string]any
+}
+
+type fixtureTable struct {
+	name string
+	rows []fixtureRow
+	deps map[string]bool
+}
+
+// LoadFixtures inserts the rows of the fixture files at paths into db in a
+// single transaction, so either every row is inserted or none is.
+func LoadFixtures(ctx context.Context, db *gorm.DB, paths ...string) error {
+	var (
+		tables []*fixtureTable
+		byName = make(map[string]*fixtureTable)
+	)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read fixture %s failed: %w", path, err)
+		}
+		if err := parseFixtures(content, byName, &tables); err != nil {
+			return fmt.Errorf("parse fixture %s failed: %w", path, err)
+		}
+	}
+
+	ordered, err := sortFixtureTables(tables, byName)
+	if err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := make(map[string]any)
+		for _, table := range ordered {
+			for i, row := range table.rows {
+				values, err := resolveFixtureRefs(row.values, ids)
+				if err != nil {
+					return fmt.Errorf("fixture %s[%d]: %w", table.name, i, err)
+				}
+				if err := insertFixtureRow(tx, table.name, values); err != nil {
+					return fmt.Errorf("insert fixture %s[%d] failed: %w", table.name, i, err)
+				}
+				if row.label == "" {
+					continue
+				}
+				id, ok := fixtureRowID(values)
+				if !ok {
+					return fmt.Errorf("fixture %s.%s: cannot determine the inserted id", table.name, row.label)
+				}
+				ids[table.name+"."+row.label] = id
+			}
+		}
+		return nil
+	})
+}
+
+// parseFixtures decodes one fixture file, keeping the order of tables and rows.
+func parseFixtures(content []byte, byName map[string]*fixtureTable, tables *[]*fixtureTable) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("fixture must be a mapping of table names to rows")
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		name := root.Content[i].Value
+		var rows []map[string]any
+		if err := root.Content[i+1].Decode(&rows); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+
+		table, ok := byName[name]
+		if !ok {
+			table = &fixtureTable{name: name, deps: make(map[string]bool)}
+			byName[name] = table
+			*tables = append(*tables, table)
+		}
+		for _, values := range rows {
+			row := fixtureRow{values: values}
+			if label, ok := values[fixtureLabelKey]; ok {
+				row.label = fmt.Sprint(label)
+				delete(values, fixtureLabelKey)
+			}
+			for _, v := range values {
+				if ref, ok := fixtureRef(v); ok {
+					if dep, _, found := strings.Cut(ref, "."); found && dep != name {
+						table.deps[dep] = true
+					}
+				}
+			}
+			table.rows = append(table.rows, row)
+		}
+	}
+	return nil
+}
+
+// sortFixtureTables orders tables so that every table follows the tables it references.
+// Tables without a dependency between them keep their order of appearance.
+func sortFixtureTables(tables []*fixtureTable, byName map[string]*fixtureTable) ([]*fixtureTable, error) {
+	ordered := make([]*fixtureTable, 0, len(tables))
+	done := make(map[string]bool, len(tables))
+	for len(ordered) < len(tables) {
+		progressed := false
+		for _, table := range tables {
+			if done[table.name] || !fixtureDepsDone(table, byName, done) {
+				continue
+			}
+			ordered = append(ordered, table)
+			done[table.name] = true
+			progressed = true
+		}
+		if !progressed {
+			var pending []string
+			for _, table := range tables {
+				if !done[table.name] {
+					pending = append(pending, table.name)
+				}
+			}
+			return nil, fmt.Errorf("fixture tables have a reference cycle: %s", strings.Join(pending, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+func fixtureDepsDone(table *fixtureTable, byName map[string]*fixtureTable, done map[string]bool) bool {
+	for dep := range table.deps {
+		// References to tables without fixtures fail when the row is resolved.
+		if _, ok := byName[dep]; ok && !done[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// fixtureRef returns the target of a {ref: table.label} value.
+func fixtureRef(v any) (string, bool) {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 1 {
+		return "", false
+	}
+	ref, ok := m[fixtureRefKey].(string)
+	return ref, ok
+}
+
+func resolveFixtureRefs(values map[string]any, ids map[string]any) (map[string]any, error) {
+	resolved := make(map[string]any, len(values))
+	for k, v := range values {
+		if ref, ok := fixtureRef(v); ok {
+			id, found := ids[ref]
+			if !found {
+				return nil, fmt.Errorf("column %s references unknown row %s", k, ref)
+			}
+			v = id
+		}
+		resolved[k] = v
+	}
+	return resolved, nil
+}
+
+// insertFixtureRow inserts one row. The returning clause fills in the id on
+// drivers that support it; gorm sets @id from LastInsertId on MySQL.
+func insertFixtureRow(tx *gorm.DB, table string, values map[string]any) error {
+	return tx.Table(table).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: fixtureIDColumn}}}).
+		Create(values).Error
+}
+
+func fixtureRowID(values map[string]any) (any, bool) {
+	if id, ok := values[fixtureIDColumn]; ok && id != nil {
+		return id, true
+	}
+	id, ok := values["@id"]
+	return id, ok && id != nil
+}
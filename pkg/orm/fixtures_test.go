@@ -0,0 +1,105 @@
+package orm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newFixtureTestDB(t *testing.T) DB {
+	t.Helper()
+	db, err := MakeDB(&DBConfig{Driver: DriverSQLite, DBName: SQLiteMemory})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	for _, stmt := range []string{
+		`CREATE TABLE users (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`,
+		`CREATE TABLE posts (id INTEGER PRIMARY KEY AUTOINCREMENT, user_id INTEGER NOT NULL REFERENCES users(id), title TEXT NOT NULL)`,
+		`CREATE TABLE comments (id INTEGER PRIMARY KEY AUTOINCREMENT, post_id INTEGER NOT NULL REFERENCES posts(id), body TEXT NOT NULL)`,
+	} {
+		require.NoError(t, db.GetDB().Exec(stmt).Error)
+	}
+	return db
+}
+
+func TestLoadFixtures(t *testing.T) {
+	db := newFixtureTestDB(t)
+
+	err := LoadFixtures(context.Background(), db.GetDB(),
+		"testdata/fixtures/posts.yaml", "testdata/fixtures/users.json")
+	require.NoError(t, err)
+
+	var titles []struct {
+		Name  string
+		Title string
+	}
+	err = db.GetDB().Raw(`SELECT users.name, posts.title FROM posts JOIN users ON users.id = posts.user_id ORDER BY posts.id`).
+		Scan(&titles).Error
+	require.NoError(t, err)
+	require.Len(t, titles, 2)
+	require.Equal(t, "Alice", titles[0].Name)
+	require.Equal(t, "Hello", titles[0].Title)
+	require.Equal(t, "Bob", titles[1].Name)
+
+	var body string
+	err = db.GetDB().Raw(`SELECT comments.body FROM comments JOIN posts ON posts.id = comments.post_id WHERE posts.title = ?`, "Hello").
+		Scan(&body).Error
+	require.NoError(t, err)
+	require.Equal(t, "Nice post", body)
+}
+
+func TestLoadFixtures_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		err     string
+	}{
+		{
+			name:    "unknown reference",
+			fixture: "posts:\n  - user_id: {ref: users.nobody}\n    title: x\n",
+			err:     "unknown row users.nobody",
+		},
+		{
+			name:    "cycle",
+			fixture: "users:\n  - name: {ref: posts.a}\nposts:\n  - user_id: {ref: users.b}\n    title: x\n",
+			err:     "reference cycle",
+		},
+		{
+			name:    "not a mapping",
+			fixture: "- users\n",
+			err:     "mapping of table names",
+		},
+		{
+			name:    "bad column",
+			fixture: "users:\n  - missing: x\n",
+			err:     "insert fixture users[0] failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFixtureTestDB(t)
+			path := filepath.Join(t.TempDir(), "fixture.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.fixture), 0o644))
+
+			err := LoadFixtures(context.Background(), db.GetDB(), path)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestLoadFixtures_Rollback(t *testing.T) {
+	db := newFixtureTestDB(t)
+	path := filepath.Join(t.TempDir(), "fixture.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("users:\n  - name: ok\n  - missing: x\n"), 0o644))
+
+	require.Error(t, LoadFixtures(context.Background(), db.GetDB(), path))
+
+	var count int64
+	require.NoError(t, db.GetDB().Table("users").Count(&count).Error)
+	require.Zero(t, count)
+}
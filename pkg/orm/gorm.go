@@ -7,9 +7,9 @@ import (
 	"strings"
 	"time"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 type DBUtil interface {
@@ -21,12 +21,14 @@ type DBUtil interface {
 
 type DB interface {
 	GetDB() *gorm.DB
-	ClearAllData() error
+	ClearAllData(opts ...ClearOption) error
 	Close() error
 }
 
 // DBConfig is the configuration for the database
 type DBConfig struct {
+	// Driver is one of DriverMySQL, DriverPostgres and DriverSQLite, defaulting to DriverMySQL.
+	Driver          string
 	Username        string
 	Password        string
 	Host            string
@@ -37,6 +39,12 @@ type DBConfig struct {
 	DBCharset       string
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+	// SSLMode is the PostgreSQL sslmode, defaulting to disable.
+	SSLMode string
+	// Replicas serve reads. Writes, transactions and locking reads go to the primary.
+	Replicas []ReplicaConfig
+	// ReplicaHealthCheckInterval is how often replicas are pinged, defaulting to 10 seconds.
+	ReplicaHealthCheckInterval time.Duration
 }
 
 // getCharset returns the charset, defaulting to utf8mb4
@@ -63,23 +71,37 @@ func (c *DBConfig) getConnMaxIdleTime() time.Duration {
 	return c.ConnMaxIdleTime
 }
 
-// quoteIdentifier escapes a SQL identifier to prevent SQL injection
-func quoteIdentifier(name string) string {
-	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+// getReplicaHealthCheckInterval returns the replica health check interval, defaulting to 10 seconds
+func (c *DBConfig) getReplicaHealthCheckInterval() time.Duration {
+	if c.ReplicaHealthCheckInterval == 0 {
+		return 10 * time.Second
+	}
+	return c.ReplicaHealthCheckInterval
+}
+
+// getSSLMode returns the PostgreSQL sslmode, defaulting to disable
+func (c *DBConfig) getSSLMode() string {
+	if c.SSLMode == "" {
+		return "disable"
+	}
+	return c.SSLMode
 }
 
 func MakeDBUtil(dbConfig *DBConfig) (DBUtil, error) {
-	return newGormMysql(dbConfig, true)
+	return newGormDB(dbConfig, true)
 }
 
 func MakeDB(dbConfig *DBConfig) (DB, error) {
-	return newGormMysql(dbConfig, false)
+	return newGormDB(dbConfig, false)
 }
 
-func newGormMysql(dbConfig *DBConfig, forUtil bool) (*gormMysql, error) {
-	gm := &gormMysql{dbConfig: dbConfig}
+func newGormDB(dbConfig *DBConfig, forUtil bool) (*gormDB, error) {
+	d, err := dbConfig.getDriver()
+	if err != nil {
+		return nil, err
+	}
+	gm := &gormDB{dbConfig: dbConfig, driver: d}
 
-	var err error
 	if forUtil {
 		err = gm.initUtilDB()
 	} else {
@@ -93,15 +115,23 @@ func newGormMysql(dbConfig *DBConfig, forUtil bool) (*gormMysql, error) {
 	return gm, nil
 }
 
-type gormMysql struct {
+type gormDB struct {
 	dbConfig *DBConfig
+	driver   driver
 	db       *gorm.DB
 	utilDB   *gorm.DB
 	sqlDB    *sql.DB
+	replicas *replicaSet
 }
 
 // Close closes the database connection
-func (gm *gormMysql) Close() error {
+func (gm *gormDB) Close() error {
+	if gm.replicas != nil {
+		if err := gm.replicas.close(); err != nil {
+			return fmt.Errorf("close replicas failed: %w", err)
+		}
+		gm.replicas = nil
+	}
 	if gm.sqlDB != nil {
 		return gm.sqlDB.Close()
 	}
@@ -109,17 +139,12 @@ func (gm *gormMysql) Close() error {
 }
 
 // CreateDB creates the database if it does not exist
-func (gm *gormMysql) CreateDB() error {
+func (gm *gormDB) CreateDB() error {
 	if gm.utilDB == nil {
 		return fmt.Errorf("util db is nil, please use MakeDBUtil first")
 	}
 
-	dbName := quoteIdentifier(gm.dbConfig.DBName)
-	charset := gm.dbConfig.getCharset()
-	createDBSQL := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s DEFAULT CHARSET %s COLLATE %s_general_ci;",
-		dbName, charset, charset)
-
-	if err := gm.utilDB.Exec(createDBSQL).Error; err != nil {
+	if err := gm.getDriver().createDB(gm.utilDB, gm.dbConfig); err != nil {
 		return fmt.Errorf("create db failed: %w", err)
 	}
 
@@ -127,15 +152,12 @@ func (gm *gormMysql) CreateDB() error {
 }
 
 // DropDB drops the database if it exists
-func (gm *gormMysql) DropDB() error {
+func (gm *gormDB) DropDB() error {
 	if gm.utilDB == nil {
 		return fmt.Errorf("util db is nil, please use MakeDBUtil first")
 	}
 
-	dbName := quoteIdentifier(gm.dbConfig.DBName)
-	dropDBSQL := fmt.Sprintf("DROP DATABASE IF EXISTS %s;", dbName)
-
-	if err := gm.utilDB.Exec(dropDBSQL).Error; err != nil {
+	if err := gm.getDriver().dropDB(gm.utilDB, gm.dbConfig); err != nil {
 		return fmt.Errorf("drop db failed: %w", err)
 	}
 
@@ -143,22 +165,49 @@ func (gm *gormMysql) DropDB() error {
 }
 
 // GetUtilDB returns the utility database connection for database management operations
-func (gm *gormMysql) GetUtilDB() *gorm.DB {
+func (gm *gormDB) GetUtilDB() *gorm.DB {
 	return gm.utilDB
 }
 
 // GetDB returns the main database connection
-func (gm *gormMysql) GetDB() *gorm.DB {
+func (gm *gormDB) GetDB() *gorm.DB {
 	return gm.db
 }
 
-// ClearAllData clears all data from all tables (only works in test environment with test/dev database)
-func (gm *gormMysql) ClearAllData() error {
+// ClearOption is ClearAllData option.
+type ClearOption func(*clearOptions)
+
+type clearOptions struct {
+	truncate bool
+	exclude  map[string]bool
+}
+
+// WithTruncate truncates the tables with foreign key checks disabled instead
+// of deleting their rows one table at a time. It is faster, ignores foreign
+// key order and resets auto-increment counters.
+func WithTruncate() ClearOption {
+	return func(o *clearOptions) {
+		o.truncate = true
+	}
+}
+
+// WithExcludeTables keeps the data of tables, such as DefaultMigrationTable.
+func WithExcludeTables(tables ...string) ClearOption {
+	return func(o *clearOptions) {
+		for _, t := range tables {
+			o.exclude[t] = true
+		}
+	}
+}
+
+// ClearAllData clears all data from all tables, skipping views (only works in test environment with test/dev database)
+func (gm *gormDB) ClearAllData(opts ...ClearOption) error {
 	if flag.Lookup("test.v") == nil {
 		return fmt.Errorf("ClearAllData can only be called in test environment")
 	}
 
-	if !strings.Contains(gm.dbConfig.DBName, "test") && !strings.Contains(gm.dbConfig.DBName, "dev") {
+	if !gm.dbConfig.isSQLiteMemory() &&
+		!strings.Contains(gm.dbConfig.DBName, "test") && !strings.Contains(gm.dbConfig.DBName, "dev") {
 		return fmt.Errorf("ClearAllData can only be used with test or dev database, got: %s", gm.dbConfig.DBName)
 	}
 
@@ -166,72 +215,144 @@ func (gm *gormMysql) ClearAllData() error {
 		return fmt.Errorf("db is nil, please init db first")
 	}
 
-	rs, err := gm.db.Raw("SHOW TABLES;").Rows()
-	if err != nil {
-		return fmt.Errorf("get table list failed: %w", err)
+	o := &clearOptions{exclude: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(o)
 	}
-	defer rs.Close()
 
-	var tName string
-	for rs.Next() {
-		if err := rs.Scan(&tName); err != nil {
-			return fmt.Errorf("scan table name failed: %w", err)
+	all, err := gm.listTables()
+	if err != nil {
+		return err
+	}
+	tables := make([]string, 0, len(all))
+	for _, tName := range all {
+		if !o.exclude[tName] {
+			tables = append(tables, tName)
 		}
-		if tName == "" {
-			continue
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	if o.truncate {
+		// Session settings such as disabled foreign key checks need one connection.
+		err := gm.db.Connection(func(conn *gorm.DB) error {
+			return gm.getDriver().truncateTables(conn, tables)
+		})
+		if err != nil {
+			return fmt.Errorf("truncate tables failed: %w", err)
 		}
+		return nil
+	}
 
-		quotedTable := quoteIdentifier(tName)
+	for _, tName := range tables {
+		quotedTable := gm.getDriver().quoteIdentifier(tName)
 		if err := gm.db.Exec(fmt.Sprintf("DELETE FROM %s", quotedTable)).Error; err != nil {
 			return fmt.Errorf("clear data from table %s failed: %w", tName, err)
 		}
 	}
 
+	return nil
+}
+
+// listTables returns the base tables of the database, excluding views. The rows are read before any
+// other statement runs, since an in-memory SQLite pool holds a single connection.
+func (gm *gormDB) listTables() ([]string, error) {
+	rs, err := gm.db.Raw(gm.getDriver().listTablesSQL()).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("get table list failed: %w", err)
+	}
+	defer rs.Close()
+
+	var tables []string
+	for rs.Next() {
+		var tName string
+		if err := rs.Scan(&tName); err != nil {
+			return nil, fmt.Errorf("scan table name failed: %w", err)
+		}
+		if tName != "" {
+			tables = append(tables, tName)
+		}
+	}
+
 	if err := rs.Err(); err != nil {
-		return fmt.Errorf("iterate tables failed: %w", err)
+		return nil, fmt.Errorf("iterate tables failed: %w", err)
 	}
 
-	return nil
+	return tables, nil
 }
 
-// openConnection creates a new database connection with the given DSN
-func (gm *gormMysql) openConnection(dsn string, silent bool) (gormDB *gorm.DB, sqlDB *sql.DB, err error) {
-	sqlDB, err = sql.Open("mysql", dsn)
+// getDriver returns the driver, resolving it from the config when unset
+func (gm *gormDB) getDriver() driver {
+	if gm.driver == nil {
+		gm.driver, _ = gm.dbConfig.getDriver()
+		if gm.driver == nil {
+			gm.driver = mysqlDriver{}
+		}
+	}
+	return gm.driver
+}
+
+// openSQL opens a connection pool with the configured pool settings
+func (gm *gormDB) openSQL(dsn string) (*sql.DB, error) {
+	sqlDB, err := sql.Open(gm.getDriver().sqlDriverName(), dsn)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect database: %w", err)
+		return nil, fmt.Errorf("failed to connect database: %w", err)
+	}
+
+	if gm.dbConfig.isSQLiteMemory() {
+		// Every connection to :memory: is a separate database, which is
+		// dropped with its last connection, so keep exactly one open forever.
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+		return sqlDB, nil
 	}
 
 	sqlDB.SetMaxIdleConns(gm.dbConfig.MaxIdleConns)
 	sqlDB.SetMaxOpenConns(gm.dbConfig.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(gm.dbConfig.getConnMaxLifetime())
 	sqlDB.SetConnMaxIdleTime(gm.dbConfig.getConnMaxIdleTime())
+	return sqlDB, nil
+}
+
+// openConnection creates a new database connection with the given DSN
+func (gm *gormDB) openConnection(dsn string, silent bool) (db *gorm.DB, sqlDB *sql.DB, err error) {
+	sqlDB, err = gm.openSQL(dsn)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	gormConfig := &gorm.Config{}
 	if silent {
 		gormConfig.Logger = logger.Default.LogMode(logger.Silent)
 	}
 
-	gormDB, err = gorm.Open(mysql.New(mysql.Config{Conn: sqlDB}), gormConfig)
+	db, err = gorm.Open(gm.getDriver().dialector(sqlDB), gormConfig)
 	if err != nil {
 		sqlDB.Close()
 		return nil, nil, fmt.Errorf("failed to open gorm: %w", err)
 	}
 
-	return gormDB, sqlDB, nil
+	return db, sqlDB, nil
 }
 
-// buildDSN constructs a MySQL DSN string
-func (gm *gormMysql) buildDSN(dbName string) string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
-		gm.dbConfig.Username,
-		gm.dbConfig.Password,
-		gm.dbConfig.Host,
-		gm.dbConfig.Port,
-		dbName,
-		gm.dbConfig.getCharset())
+// buildDSN constructs the DSN string of the primary
+func (gm *gormDB) buildDSN(dbName string) string {
+	return gm.getDriver().buildDSN(gm.dbConfig, gm.dbConfig.primaryHost(), dbName)
 }
 
-func (gm *gormMysql) initGormDB() error {
+// buildReplicaDSN constructs the DSN string of a replica
+func (gm *gormDB) buildReplicaDSN(r ReplicaConfig) string {
+	h := hostConfig(r)
+	if h.Username == "" {
+		h.Username, h.Password = gm.dbConfig.Username, gm.dbConfig.Password
+	}
+	return gm.getDriver().buildDSN(gm.dbConfig, h, gm.dbConfig.DBName)
+}
+
+func (gm *gormDB) initGormDB() error {
 	if gm.db != nil {
 		return fmt.Errorf("gorm db already initialized")
 	}
@@ -244,15 +365,69 @@ func (gm *gormMysql) initGormDB() error {
 
 	gm.db = db
 	gm.sqlDB = sqlDB
+
+	if err := gm.initReplicas(); err != nil {
+		sqlDB.Close()
+		gm.db, gm.sqlDB = nil, nil
+		return err
+	}
+	return nil
+}
+
+// initReplicas registers the replicas with dbresolver and starts their health check
+func (gm *gormDB) initReplicas() error {
+	if len(gm.dbConfig.Replicas) == 0 {
+		return nil
+	}
+	if _, ok := gm.getDriver().(sqliteDriver); ok {
+		return fmt.Errorf("replicas are not supported by the sqlite driver")
+	}
+
+	dbs := make([]*sql.DB, 0, len(gm.dbConfig.Replicas))
+	dialectors := make([]gorm.Dialector, 0, len(gm.dbConfig.Replicas)+1)
+	for _, r := range gm.dbConfig.Replicas {
+		replicaDB, err := gm.openSQL(gm.buildReplicaDSN(r))
+		if err != nil {
+			for _, db := range dbs {
+				db.Close()
+			}
+			return err
+		}
+		dbs = append(dbs, replicaDB)
+		dialectors = append(dialectors, gm.getDriver().replicaDialector(replicaDB))
+	}
+	// The primary is the fallback when every replica is unhealthy.
+	dialectors = append(dialectors, gm.getDriver().replicaDialector(gm.sqlDB))
+
+	replicas := newReplicaSet(gm.sqlDB, dbs, gm.dbConfig.getReplicaHealthCheckInterval())
+
+	// dbresolver opens every dialector with the primary's config, which pings by default.
+	// Skip the ping so that a replica being down at startup does not fail the service.
+	disablePing := gm.db.Config.DisableAutomaticPing
+	gm.db.Config.DisableAutomaticPing = true
+	err := gm.db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   replicas.policy,
+	}))
+	gm.db.Config.DisableAutomaticPing = disablePing
+	if err != nil {
+		for _, db := range dbs {
+			db.Close()
+		}
+		return fmt.Errorf("register replicas failed: %w", err)
+	}
+
+	replicas.start()
+	gm.replicas = replicas
 	return nil
 }
 
-func (gm *gormMysql) initUtilDB() error {
+func (gm *gormDB) initUtilDB() error {
 	if gm.utilDB != nil {
 		return fmt.Errorf("util db already initialized")
 	}
 
-	dsn := gm.buildDSN("information_schema")
+	dsn := gm.buildDSN(gm.getDriver().utilDBName(gm.dbConfig))
 	db, sqlDB, err := gm.openConnection(dsn, false)
 	if err != nil {
 		return err
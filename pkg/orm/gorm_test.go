@@ -64,7 +64,7 @@ func TestMakeDB(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestQuoteIdentifier(t *testing.T) {
+func TestMysqlDriver_quoteIdentifier(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected string
@@ -76,7 +76,7 @@ func TestQuoteIdentifier(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := quoteIdentifier(tt.input)
+		result := mysqlDriver{}.quoteIdentifier(tt.input)
 		require.Equal(t, tt.expected, result)
 	}
 }
@@ -174,7 +174,7 @@ func TestDBConfig_getConnMaxIdleTime(t *testing.T) {
 	}
 }
 
-func TestGormMysql_GetUtilDB(t *testing.T) {
+func TestGormDB_GetUtilDB(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "root",
 		Password:     password,
@@ -190,13 +190,13 @@ func TestGormMysql_GetUtilDB(t *testing.T) {
 	require.NoError(t, err)
 	defer utilDB.Close()
 
-	gm := utilDB.(*gormMysql)
+	gm := utilDB.(*gormDB)
 	result := gm.GetUtilDB()
 	require.NotNil(t, result)
 	require.Equal(t, gm.utilDB, result)
 }
 
-func TestGormMysql_GetDB(t *testing.T) {
+func TestGormDB_GetDB(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "root",
 		Password:     password,
@@ -223,13 +223,13 @@ func TestGormMysql_GetDB(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	gm := db.(*gormMysql)
+	gm := db.(*gormDB)
 	result := gm.GetDB()
 	require.NotNil(t, result)
 	require.Equal(t, gm.db, result)
 }
 
-func TestGormMysql_CreateDB_Error(t *testing.T) {
+func TestGormDB_CreateDB_Error(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "root",
 		Password:     password,
@@ -241,15 +241,15 @@ func TestGormMysql_CreateDB_Error(t *testing.T) {
 		DBCharset:    "utf8mb4",
 	}
 
-	// Create a gormMysql instance without initializing utilDB
-	gm := &gormMysql{dbConfig: dbConf}
+	// Create a gormDB instance without initializing utilDB
+	gm := &gormDB{dbConfig: dbConf}
 
 	err := gm.CreateDB()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "util db is nil")
 }
 
-func TestGormMysql_DropDB_Error(t *testing.T) {
+func TestGormDB_DropDB_Error(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "root",
 		Password:     password,
@@ -261,15 +261,15 @@ func TestGormMysql_DropDB_Error(t *testing.T) {
 		DBCharset:    "utf8mb4",
 	}
 
-	// Create a gormMysql instance without initializing utilDB
-	gm := &gormMysql{dbConfig: dbConf}
+	// Create a gormDB instance without initializing utilDB
+	gm := &gormDB{dbConfig: dbConf}
 
 	err := gm.DropDB()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "util db is nil")
 }
 
-func TestGormMysql_ClearAllData_Error(t *testing.T) {
+func TestGormDB_ClearAllData_Error(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "root",
 		Password:     password,
@@ -296,13 +296,13 @@ func TestGormMysql_ClearAllData_Error(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	gm := db.(*gormMysql)
+	gm := db.(*gormDB)
 	err = gm.ClearAllData()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "test or dev database")
 }
 
-func TestGormMysql_ClearAllData_DBNil(t *testing.T) {
+func TestGormDB_ClearAllData_DBNil(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "root",
 		Password:     password,
@@ -314,15 +314,15 @@ func TestGormMysql_ClearAllData_DBNil(t *testing.T) {
 		DBCharset:    "utf8mb4",
 	}
 
-	// Create a gormMysql instance without initializing db
-	gm := &gormMysql{dbConfig: dbConf}
+	// Create a gormDB instance without initializing db
+	gm := &gormDB{dbConfig: dbConf}
 
 	err := gm.ClearAllData()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "db is nil")
 }
 
-func TestGormMysql_Close(t *testing.T) {
+func TestGormDB_Close(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "root",
 		Password:     password,
@@ -345,14 +345,14 @@ func TestGormMysql_Close(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestGormMysql_Close_Nil(t *testing.T) {
-	gm := &gormMysql{}
+func TestGormDB_Close_Nil(t *testing.T) {
+	gm := &gormDB{}
 
 	err := gm.Close()
 	require.NoError(t, err)
 }
 
-func TestGormMysql_buildDSN(t *testing.T) {
+func TestGormDB_buildDSN(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "testuser",
 		Password:     "testpass",
@@ -364,7 +364,7 @@ func TestGormMysql_buildDSN(t *testing.T) {
 		DBCharset:    "utf8",
 	}
 
-	gm := &gormMysql{dbConfig: dbConf}
+	gm := &gormDB{dbConfig: dbConf}
 
 	tests := []struct {
 		name     string
@@ -396,7 +396,7 @@ func TestGormMysql_buildDSN(t *testing.T) {
 	}
 }
 
-func TestGormMysql_buildDSN_DefaultCharset(t *testing.T) {
+func TestGormDB_buildDSN_DefaultCharset(t *testing.T) {
 	dbConf := &DBConfig{
 		Username:     "user",
 		Password:     "pass",
@@ -408,7 +408,7 @@ func TestGormMysql_buildDSN_DefaultCharset(t *testing.T) {
 		DBCharset:    "", // Empty charset should default to utf8mb4
 	}
 
-	gm := &gormMysql{dbConfig: dbConf}
+	gm := &gormDB{dbConfig: dbConf}
 
 	result := gm.buildDSN("testdb")
 	require.Contains(t, result, "charset=utf8mb4")
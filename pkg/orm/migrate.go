@@ -0,0 +1,376 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultMigrationTable is the table recording applied migrations.
+const DefaultMigrationTable = "schema_migrations"
+
+// migrationFileRe matches migration file names such as 000001_create_greeters.up.sql.
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is the state of one migration in the database.
+type MigrationStatus struct {
+	Version   uint64
+	Name      string
+	Applied   bool
+	Dirty     bool
+	AppliedAt time.Time
+}
+
+// schemaMigration is a row of the migration table.
+type schemaMigration struct {
+	Version   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"size:255;not null"`
+	Dirty     bool   `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+// LoadMigrations reads the *.up.sql and *.down.sql files in dir of fsys,
+// sorted by version. Every version must have an up file.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir failed: %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m := migrationFileRe.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse migration version %s failed: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s failed: %w", entry.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(content)
+		} else {
+			mig.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if strings.TrimSpace(mig.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// splitStatements splits a SQL script into statements on semicolons that end a line.
+// Comment-only and empty statements are dropped.
+func splitStatements(script string) []string {
+	var (
+		stmts   []string
+		current strings.Builder
+	)
+	flush := func() {
+		stmt := strings.TrimSpace(current.String())
+		current.Reset()
+		if stmt == "" || isCommentOnly(stmt) {
+			return
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	for _, line := range strings.Split(script, "\n") {
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			flush()
+		}
+	}
+	flush()
+	return stmts
+}
+
+func isCommentOnly(stmt string) bool {
+	for _, line := range strings.Split(stmt, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+	return true
+}
+
+// MigratorOption is Migrator option.
+type MigratorOption func(*Migrator)
+
+// WithMigrationTable sets the table recording applied migrations.
+func WithMigrationTable(table string) MigratorOption {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// Migrator applies and reverts versioned migrations.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	table      string
+}
+
+// NewMigrator creates a Migrator that runs the migrations found in dir of fsys.
+func NewMigrator(db *gorm.DB, fsys fs.FS, dir string, opts ...MigratorOption) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{
+		db:         db,
+		migrations: migrations,
+		table:      DefaultMigrationTable,
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m, nil
+}
+
+// Up applies up to steps pending migrations in version order.
+// A steps value <= 0 applies all of them. It returns the number of applied migrations.
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkDirty(applied); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mig := range m.migrations {
+		if steps > 0 && count >= steps {
+			break
+		}
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if err := m.run(ctx, mig, true); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Down reverts up to steps applied migrations in reverse version order, and
+// returns the number of reverted migrations. steps must be positive; use
+// DownAll to revert all of them.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("down steps must be positive, got %d", steps)
+	}
+	return m.down(ctx, steps)
+}
+
+// DownAll reverts all applied migrations in reverse version order, and
+// returns the number of reverted migrations.
+func (m *Migrator) DownAll(ctx context.Context) (int, error) {
+	return m.down(ctx, 0)
+}
+
+// down reverts up to steps applied migrations, or all of them when steps is 0.
+func (m *Migrator) down(ctx context.Context, steps int) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkDirty(applied); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if steps > 0 && count >= steps {
+			break
+		}
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		if err := m.run(ctx, mig, false); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Status returns the state of every known migration.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st := MigrationStatus{Version: mig.Version, Name: mig.Name}
+		if row, ok := applied[mig.Version]; ok {
+			st.Applied = true
+			st.Dirty = row.Dirty
+			st.AppliedAt = row.AppliedAt
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// Force records version and every migration before it as cleanly applied and
+// every later migration as not applied, without running any SQL.
+// Use it to recover from a dirty migration after fixing the schema by hand.
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(m.table).Where("version > ?", version).Delete(&schemaMigration{}).Error; err != nil {
+			return fmt.Errorf("delete migrations after %d failed: %w", version, err)
+		}
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			row := &schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}
+			err := tx.Table(m.table).Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "version"}},
+				DoUpdates: clause.AssignmentColumns([]string{"dirty"}),
+			}).Create(row).Error
+			if err != nil {
+				return fmt.Errorf("force migration %d failed: %w", mig.Version, err)
+			}
+		}
+		return nil
+	})
+}
+
+// run executes one migration in the given direction. The migration is marked
+// dirty before its statements run, because DDL is not transactional in MySQL.
+func (m *Migrator) run(ctx context.Context, mig Migration, up bool) error {
+	if !up && strings.TrimSpace(mig.Down) == "" {
+		return fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+	}
+	row := &schemaMigration{Version: mig.Version, Name: mig.Name, Dirty: true, AppliedAt: time.Now()}
+	if err := m.tableDB(ctx).Save(row).Error; err != nil {
+		return fmt.Errorf("mark migration %d dirty failed: %w", mig.Version, err)
+	}
+
+	script, direction := mig.Up, "up"
+	if !up {
+		script, direction = mig.Down, "down"
+	}
+	for _, stmt := range splitStatements(script) {
+		if err := m.db.WithContext(ctx).Exec(stmt).Error; err != nil {
+			return fmt.Errorf("migration %d_%s %s failed: %w", mig.Version, mig.Name, direction, err)
+		}
+	}
+
+	if !up {
+		if err := m.tableDB(ctx).Where("version = ?", mig.Version).Delete(&schemaMigration{}).Error; err != nil {
+			return fmt.Errorf("delete migration %d failed: %w", mig.Version, err)
+		}
+		return nil
+	}
+	if err := m.tableDB(ctx).Where("version = ?", mig.Version).Update("dirty", false).Error; err != nil {
+		return fmt.Errorf("mark migration %d clean failed: %w", mig.Version, err)
+	}
+	return nil
+}
+
+// tableDB returns a new session on the migration table.
+func (m *Migrator) tableDB(ctx context.Context) *gorm.DB {
+	return m.db.WithContext(ctx).Table(m.table)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	if err := m.tableDB(ctx).AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("create migration table failed: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[uint64]schemaMigration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var rows []schemaMigration
+	if err := m.tableDB(ctx).Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list applied migrations failed: %w", err)
+	}
+
+	applied := make(map[uint64]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) find(version uint64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func checkDirty(applied map[uint64]schemaMigration) error {
+	for _, row := range applied {
+		if row.Dirty {
+			return fmt.Errorf("migration %d_%s is dirty, fix the schema and run force", row.Version, row.Name)
+		}
+	}
+	return nil
+}
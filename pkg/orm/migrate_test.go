@@ -0,0 +1,237 @@
+package orm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrationsFS = fstest.MapFS{
+	"migrations/000001_create_users.up.sql": {Data: []byte(
+		"-- users table\nCREATE TABLE users (\n  id BIGINT NOT NULL,\n  PRIMARY KEY (id)\n);\n",
+	)},
+	"migrations/000001_create_users.down.sql": {Data: []byte("DROP TABLE users;\n")},
+	"migrations/000002_create_posts.up.sql": {Data: []byte(
+		"CREATE TABLE posts (id BIGINT NOT NULL, PRIMARY KEY (id));\nCREATE TABLE tags (id BIGINT NOT NULL, PRIMARY KEY (id));\n",
+	)},
+	"migrations/000002_create_posts.down.sql": {Data: []byte("DROP TABLE tags;\nDROP TABLE posts;\n")},
+	"migrations/README.md":                    {Data: []byte("ignored")},
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(testMigrationsFS, "migrations")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	require.Equal(t, uint64(1), migrations[0].Version)
+	require.Equal(t, "create_users", migrations[0].Name)
+	require.Contains(t, migrations[0].Up, "CREATE TABLE users")
+	require.Contains(t, migrations[0].Down, "DROP TABLE users")
+	require.Equal(t, uint64(2), migrations[1].Version)
+}
+
+func TestLoadMigrations_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		err  string
+	}{
+		{
+			name: "missing up",
+			fsys: fstest.MapFS{"m/000001_a.down.sql": {Data: []byte("DROP TABLE a;")}},
+			err:  "has no up file",
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"m/000001_a.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+				"m/000001_b.down.sql": {Data: []byte("DROP TABLE a;")},
+			},
+			err: "conflicting names",
+		},
+		{
+			name: "missing dir",
+			fsys: fstest.MapFS{},
+			err:  "read migrations dir failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.fsys, "m")
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := "-- comment only\n\nCREATE TABLE a (\n  id INT\n);\nINSERT INTO a VALUES (1); \n-- trailing\nSELECT 1"
+
+	stmts := splitStatements(script)
+	require.Equal(t, []string{
+		"-- comment only\n\nCREATE TABLE a (\n  id INT\n);",
+		"INSERT INTO a VALUES (1);",
+		"-- trailing\nSELECT 1",
+	}, stmts)
+}
+
+func TestMigrator(t *testing.T) {
+	dbConf := &DBConfig{
+		Username:     "root",
+		Password:     password,
+		Host:         "127.0.0.1",
+		Port:         "3306",
+		DBName:       "migrate_test",
+		MaxIdleConns: 10,
+		MaxOpenConns: 100,
+		DBCharset:    "utf8mb4",
+	}
+
+	utilDB, err := MakeDBUtil(dbConf)
+	require.NoError(t, err)
+	defer utilDB.Close()
+
+	require.NoError(t, utilDB.DropDB())
+	require.NoError(t, utilDB.CreateDB())
+	defer func() {
+		require.NoError(t, utilDB.DropDB())
+	}()
+
+	db, err := MakeDB(dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	migrator, err := NewMigrator(db.GetDB(), testMigrationsFS, "migrations")
+	require.NoError(t, err)
+
+	n, err := migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.True(t, db.GetDB().Migrator().HasTable("users"))
+	require.False(t, db.GetDB().Migrator().HasTable("posts"))
+
+	n, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.True(t, db.GetDB().Migrator().HasTable("tags"))
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	for _, st := range statuses {
+		require.True(t, st.Applied)
+		require.False(t, st.Dirty)
+	}
+
+	n, err = migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.False(t, db.GetDB().Migrator().HasTable("posts"))
+	require.True(t, db.GetDB().Migrator().HasTable("users"))
+
+	// Force records migrations as applied without running them.
+	require.NoError(t, migrator.Force(ctx, 2))
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	require.True(t, statuses[1].Applied)
+	require.False(t, db.GetDB().Migrator().HasTable("posts"))
+
+	require.NoError(t, migrator.Force(ctx, 1))
+	// Reverting everything takes DownAll, not a zero or negative count.
+	for _, steps := range []int{0, -1} {
+		_, err = migrator.Down(ctx, steps)
+		require.Error(t, err)
+	}
+	require.True(t, db.GetDB().Migrator().HasTable("users"))
+	n, err = migrator.DownAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.False(t, db.GetDB().Migrator().HasTable("users"))
+
+	require.Error(t, migrator.Force(ctx, 99))
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	dbConf := &DBConfig{
+		Username:     "root",
+		Password:     password,
+		Host:         "127.0.0.1",
+		Port:         "3306",
+		DBName:       "migrate_dirty_test",
+		MaxIdleConns: 10,
+		MaxOpenConns: 100,
+		DBCharset:    "utf8mb4",
+	}
+
+	utilDB, err := MakeDBUtil(dbConf)
+	require.NoError(t, err)
+	defer utilDB.Close()
+
+	require.NoError(t, utilDB.DropDB())
+	require.NoError(t, utilDB.CreateDB())
+	defer func() {
+		require.NoError(t, utilDB.DropDB())
+	}()
+
+	db, err := MakeDB(dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"m/000001_broken.up.sql": {Data: []byte("CREATE TABLE broken (id INT);\nTHIS IS NOT SQL;\n")},
+	}
+	ctx := context.Background()
+	migrator, err := NewMigrator(db.GetDB(), fsys, "m")
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx, 0)
+	require.Error(t, err)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.True(t, statuses[0].Dirty)
+
+	_, err = migrator.Up(ctx, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dirty")
+
+	require.NoError(t, migrator.Force(ctx, 1))
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+}
+
+func TestMigrator_NoDownScript(t *testing.T) {
+	db, err := MakeDB(&DBConfig{
+		Driver: DriverSQLite,
+		DBName: filepath.Join(t.TempDir(), "migrate_down_test.db"),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"m/000001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id INTEGER NOT NULL, PRIMARY KEY (id));\n")},
+	}
+	ctx := context.Background()
+	migrator, err := NewMigrator(db.GetDB(), fsys, "m")
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+
+	// A migration without down script is not reverted, nor recorded as reverted.
+	n, err := migrator.Down(ctx, 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "migration 1_create_users has no down script")
+	require.Zero(t, n)
+	require.True(t, db.GetDB().Migrator().HasTable("users"))
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.True(t, statuses[0].Applied)
+	require.False(t, statuses[0].Dirty)
+}
@@ -0,0 +1,47 @@
+package orm
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-kratos/kratos-layout/pkg/principal"
+)
+
+// Model is a base model with audit columns and soft delete. Embed it in
+// models instead of gorm.Model next to their own primary key:
+//
+//   - created_at and updated_at are set by gorm;
+//   - created_by and updated_by are set to the principal of the statement's
+//     context on create and update, see principal.NewContext;
+//   - deleted_at makes Delete soft, and excludes deleted rows from queries
+//     unless they are Unscoped.
+//
+// The audit columns are only set through the hooks of Model, so a model
+// embedding it must not define BeforeCreate or BeforeUpdate hooks of its own
+// without calling Model's.
+type Model struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	CreatedBy string         `gorm:"size:64;not null;default:''"`
+	UpdatedBy string         `gorm:"size:64;not null;default:''"`
+}
+
+// BeforeCreate sets created_by and updated_by to the principal of the context.
+func (m *Model) BeforeCreate(tx *gorm.DB) error {
+	if id, ok := principal.FromContext(tx.Statement.Context); ok {
+		tx.Statement.SetColumn("CreatedBy", id)
+		tx.Statement.SetColumn("UpdatedBy", id)
+	}
+	return nil
+}
+
+// BeforeUpdate sets updated_by to the principal of the context. It also
+// applies to updates with a map, as long as they have a Model.
+func (m *Model) BeforeUpdate(tx *gorm.DB) error {
+	if id, ok := principal.FromContext(tx.Statement.Context); ok {
+		tx.Statement.SetColumn("UpdatedBy", id)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/go-kratos/kratos-layout/pkg/principal"
+)
+
+type auditedItem struct {
+	ID   int64 `gorm:"primaryKey;autoIncrement"`
+	Name string
+	Model
+}
+
+func newModelTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := MakeDB(&DBConfig{Driver: DriverSQLite, DBName: SQLiteMemory})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&auditedItem{}))
+	return db.GetDB()
+}
+
+func TestModel_Audit(t *testing.T) {
+	db := newModelTestDB(t)
+	alice := principal.NewContext(context.Background(), "alice")
+	bob := principal.NewContext(context.Background(), "bob")
+
+	item := &auditedItem{Name: "a"}
+	require.NoError(t, db.WithContext(alice).Create(item).Error)
+	require.Equal(t, "alice", item.CreatedBy)
+	require.Equal(t, "alice", item.UpdatedBy)
+	require.False(t, item.CreatedAt.IsZero())
+
+	// Updates with a map set updated_by too.
+	require.NoError(t, db.WithContext(bob).Model(&auditedItem{ID: item.ID}).
+		Updates(map[string]any{"name": "b"}).Error)
+	var got auditedItem
+	require.NoError(t, db.First(&got, item.ID).Error)
+	require.Equal(t, "b", got.Name)
+	require.Equal(t, "alice", got.CreatedBy)
+	require.Equal(t, "bob", got.UpdatedBy)
+
+	// Without a principal the columns are left alone.
+	require.NoError(t, db.Model(&got).Update("name", "c").Error)
+	require.NoError(t, db.First(&got, item.ID).Error)
+	require.Equal(t, "bob", got.UpdatedBy)
+
+	anonymous := &auditedItem{Name: "anonymous"}
+	require.NoError(t, db.Create(anonymous).Error)
+	require.Empty(t, anonymous.CreatedBy)
+}
+
+func TestModel_SoftDelete(t *testing.T) {
+	db := newModelTestDB(t)
+	item := &auditedItem{Name: "a"}
+	require.NoError(t, db.Create(item).Error)
+
+	require.NoError(t, db.Delete(&auditedItem{}, item.ID).Error)
+	require.ErrorIs(t, db.First(&auditedItem{}, item.ID).Error, gorm.ErrRecordNotFound)
+
+	var deleted auditedItem
+	require.NoError(t, db.Unscoped().First(&deleted, item.ID).Error)
+	require.True(t, deleted.DeletedAt.Valid)
+}
@@ -0,0 +1,77 @@
+package orm
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type mysqlDriver struct{}
+
+func (mysqlDriver) sqlDriverName() string {
+	return "mysql"
+}
+
+func (mysqlDriver) buildDSN(c *DBConfig, h hostConfig, dbName string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
+		h.Username,
+		h.Password,
+		h.Host,
+		h.Port,
+		dbName,
+		c.getCharset())
+}
+
+func (mysqlDriver) utilDBName(*DBConfig) string {
+	return "information_schema"
+}
+
+func (mysqlDriver) dialector(conn *sql.DB) gorm.Dialector {
+	return mysql.New(mysql.Config{Conn: conn})
+}
+
+func (mysqlDriver) replicaDialector(conn *sql.DB) gorm.Dialector {
+	// Skip the version query so that an unreachable replica can still be registered.
+	return mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+}
+
+func (mysqlDriver) quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (d mysqlDriver) createDB(utilDB *gorm.DB, c *DBConfig) error {
+	charset := c.getCharset()
+	createDBSQL := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s DEFAULT CHARSET %s COLLATE %s_general_ci;",
+		d.quoteIdentifier(c.DBName), charset, charset)
+	return utilDB.Exec(createDBSQL).Error
+}
+
+func (d mysqlDriver) dropDB(utilDB *gorm.DB, c *DBConfig) error {
+	return utilDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s;", d.quoteIdentifier(c.DBName))).Error
+}
+
+func (mysqlDriver) listTablesSQL() string {
+	return "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE';"
+}
+
+// truncateTables truncates one table at a time, since MySQL has no multi-table TRUNCATE.
+func (d mysqlDriver) truncateTables(conn *gorm.DB, tables []string) (err error) {
+	if err := conn.Exec("SET FOREIGN_KEY_CHECKS = 0;").Error; err != nil {
+		return err
+	}
+	defer func() {
+		if resetErr := conn.Exec("SET FOREIGN_KEY_CHECKS = 1;").Error; err == nil {
+			err = resetErr
+		}
+	}()
+
+	for _, table := range tables {
+		if err := conn.Exec(fmt.Sprintf("TRUNCATE TABLE %s;", d.quoteIdentifier(table))).Error; err != nil {
+			return fmt.Errorf("truncate table %s failed: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package orm
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the pgx database/sql driver
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type postgresDriver struct{}
+
+func (postgresDriver) sqlDriverName() string {
+	return "pgx"
+}
+
+func (postgresDriver) buildDSN(c *DBConfig, h hostConfig, dbName string) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		pgQuoteValue(h.Host),
+		pgQuoteValue(h.Port),
+		pgQuoteValue(h.Username),
+		pgQuoteValue(h.Password),
+		pgQuoteValue(dbName),
+		pgQuoteValue(c.getSSLMode()))
+}
+
+// pgQuoteValue quotes a keyword/value connection string value.
+func pgQuoteValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	return "'" + strings.ReplaceAll(v, `'`, `\'`) + "'"
+}
+
+func (postgresDriver) utilDBName(*DBConfig) string {
+	return "postgres"
+}
+
+func (postgresDriver) dialector(conn *sql.DB) gorm.Dialector {
+	return postgres.New(postgres.Config{Conn: conn})
+}
+
+func (d postgresDriver) replicaDialector(conn *sql.DB) gorm.Dialector {
+	return d.dialector(conn)
+}
+
+func (postgresDriver) quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// createDB creates the database unless it exists, since PostgreSQL has no CREATE DATABASE IF NOT EXISTS.
+func (d postgresDriver) createDB(utilDB *gorm.DB, c *DBConfig) error {
+	var count int64
+	if err := utilDB.Raw("SELECT COUNT(*) FROM pg_database WHERE datname = ?", c.DBName).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return utilDB.Exec(fmt.Sprintf("CREATE DATABASE %s ENCODING 'UTF8';", d.quoteIdentifier(c.DBName))).Error
+}
+
+func (d postgresDriver) dropDB(utilDB *gorm.DB, c *DBConfig) error {
+	return utilDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s;", d.quoteIdentifier(c.DBName))).Error
+}
+
+func (postgresDriver) listTablesSQL() string {
+	return "SELECT tablename FROM pg_tables WHERE schemaname = current_schema();"
+}
+
+// truncateTables truncates all tables in one statement, which satisfies the
+// foreign keys between them. Tables referencing them must be truncated too.
+func (d postgresDriver) truncateTables(conn *gorm.DB, tables []string) error {
+	quoted := make([]string, 0, len(tables))
+	for _, table := range tables {
+		quoted = append(quoted, d.quoteIdentifier(table))
+	}
+	return conn.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY;", strings.Join(quoted, ", "))).Error
+}
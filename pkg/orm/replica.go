@@ -0,0 +1,157 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ReplicaConfig is a read replica of the primary database.
+// Username and Password default to the primary's when empty.
+type ReplicaConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose queries are sent to the primary,
+// for reads that must see their own writes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimary reports whether ctx forces queries to the primary.
+func IsPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
+
+// UsePrimary forces the queries of db to the primary.
+func UsePrimary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write)
+}
+
+// replicaPolicy is a dbresolver.Policy that picks a random healthy replica.
+// The primary is registered as the last replica and is only picked when
+// every real replica is unhealthy, because dbresolver skips the policy when
+// there is a single replica.
+type replicaPolicy struct {
+	primary gorm.ConnPool
+
+	mu        sync.RWMutex
+	unhealthy map[gorm.ConnPool]bool
+}
+
+func newReplicaPolicy(primary gorm.ConnPool) *replicaPolicy {
+	return &replicaPolicy{
+		primary:   primary,
+		unhealthy: make(map[gorm.ConnPool]bool),
+	}
+}
+
+// Resolve implements dbresolver.Policy.
+func (p *replicaPolicy) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	p.mu.RLock()
+	healthy := make([]gorm.ConnPool, 0, len(pools))
+	for _, pool := range pools {
+		if pool != p.primary && !p.unhealthy[pool] {
+			healthy = append(healthy, pool)
+		}
+	}
+	p.mu.RUnlock()
+
+	if len(healthy) == 0 {
+		return p.primary
+	}
+	return healthy[rand.IntN(len(healthy))]
+}
+
+func (p *replicaPolicy) setHealthy(pool gorm.ConnPool, healthy bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if healthy {
+		delete(p.unhealthy, pool)
+	} else {
+		p.unhealthy[pool] = true
+	}
+}
+
+func (p *replicaPolicy) isHealthy(pool gorm.ConnPool) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !p.unhealthy[pool]
+}
+
+// replicaSet owns the replica connections and health-checks them in the background.
+type replicaSet struct {
+	dbs      []*sql.DB
+	policy   *replicaPolicy
+	interval time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newReplicaSet(primary *sql.DB, dbs []*sql.DB, interval time.Duration) *replicaSet {
+	return &replicaSet{
+		dbs:      dbs,
+		policy:   newReplicaPolicy(primary),
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// check pings every replica and updates its place in the rotation.
+func (rs *replicaSet) check() {
+	timeout := rs.interval
+	if timeout > 2*time.Second {
+		timeout = 2 * time.Second
+	}
+	for _, db := range rs.dbs {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := db.PingContext(ctx)
+		cancel()
+		rs.policy.setHealthy(db, err == nil)
+	}
+}
+
+func (rs *replicaSet) start() {
+	rs.check()
+
+	rs.wg.Add(1)
+	go func() {
+		defer rs.wg.Done()
+		ticker := time.NewTicker(rs.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-rs.stop:
+				return
+			case <-ticker.C:
+				rs.check()
+			}
+		}
+	}()
+}
+
+// close stops the health check and closes the replica connections.
+func (rs *replicaSet) close() error {
+	close(rs.stop)
+	rs.wg.Wait()
+
+	var firstErr error
+	for _, db := range rs.dbs {
+		if err := db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
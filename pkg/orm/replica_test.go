@@ -0,0 +1,139 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakePool struct {
+	gorm.ConnPool
+	name string
+}
+
+func TestReplicaPolicy_Resolve(t *testing.T) {
+	primary := &fakePool{name: "primary"}
+	r1 := &fakePool{name: "r1"}
+	r2 := &fakePool{name: "r2"}
+	pools := []gorm.ConnPool{r1, r2, primary}
+
+	policy := newReplicaPolicy(primary)
+	for i := 0; i < 20; i++ {
+		require.NotEqual(t, primary, policy.Resolve(pools))
+	}
+
+	policy.setHealthy(r1, false)
+	for i := 0; i < 20; i++ {
+		require.Equal(t, r2, policy.Resolve(pools))
+	}
+
+	policy.setHealthy(r2, false)
+	require.Equal(t, primary, policy.Resolve(pools))
+
+	policy.setHealthy(r1, true)
+	require.Equal(t, r1, policy.Resolve(pools))
+}
+
+func TestGormDB_buildReplicaDSN(t *testing.T) {
+	gm := &gormDB{dbConfig: &DBConfig{
+		Username: "user",
+		Password: "pass",
+		DBName:   "db",
+	}}
+
+	tests := []struct {
+		name     string
+		replica  ReplicaConfig
+		expected string
+	}{
+		{
+			name:     "primary credentials",
+			replica:  ReplicaConfig{Host: "replica1", Port: "3306"},
+			expected: "user:pass@tcp(replica1:3306)/db?charset=utf8mb4&parseTime=True&loc=Local",
+		},
+		{
+			name:     "own credentials",
+			replica:  ReplicaConfig{Host: "replica2", Port: "3307", Username: "ro", Password: "ropass"},
+			expected: "ro:ropass@tcp(replica2:3307)/db?charset=utf8mb4&parseTime=True&loc=Local",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, gm.buildReplicaDSN(tt.replica))
+		})
+	}
+}
+
+func TestPrimaryContext(t *testing.T) {
+	ctx := context.Background()
+	require.False(t, IsPrimary(ctx))
+	require.True(t, IsPrimary(WithPrimary(ctx)))
+}
+
+func TestMakeDB_Replicas(t *testing.T) {
+	dbConf := &DBConfig{
+		Username:     "root",
+		Password:     password,
+		Host:         "127.0.0.1",
+		Port:         "3306",
+		DBName:       "replica_test",
+		MaxIdleConns: 10,
+		MaxOpenConns: 100,
+		DBCharset:    "utf8mb4",
+		Replicas: []ReplicaConfig{
+			{Host: "127.0.0.1", Port: "3306"},
+			// Nothing listens on port 1, so this replica must be dropped from rotation.
+			{Host: "127.0.0.1", Port: "1"},
+		},
+		ReplicaHealthCheckInterval: 50 * time.Millisecond,
+	}
+
+	utilDB, err := MakeDBUtil(dbConf)
+	require.NoError(t, err)
+	defer utilDB.Close()
+
+	require.NoError(t, utilDB.CreateDB())
+	defer func() {
+		require.NoError(t, utilDB.DropDB())
+	}()
+
+	db, err := MakeDB(dbConf)
+	require.NoError(t, err)
+	defer db.Close()
+
+	gm := db.(*gormDB)
+	require.NotNil(t, gm.replicas)
+	require.True(t, gm.replicas.policy.isHealthy(gm.replicas.dbs[0]))
+	require.False(t, gm.replicas.policy.isHealthy(gm.replicas.dbs[1]))
+
+	require.NoError(t, gm.GetDB().Exec("CREATE TABLE items (id BIGINT NOT NULL, PRIMARY KEY (id))").Error)
+	require.NoError(t, gm.GetDB().Exec("INSERT INTO items VALUES (1)").Error)
+
+	for i := 0; i < 10; i++ {
+		var count int64
+		require.NoError(t, gm.GetDB().Table("items").Count(&count).Error)
+		require.Equal(t, int64(1), count)
+	}
+
+	var count int64
+	require.NoError(t, UsePrimary(gm.GetDB()).Table("items").Count(&count).Error)
+	require.Equal(t, int64(1), count)
+
+	require.NoError(t, db.Close())
+	require.Nil(t, gm.replicas)
+}
+
+func TestReplicaSet_Check(t *testing.T) {
+	down, err := sql.Open("mysql", "root:root@tcp(127.0.0.1:1)/db")
+	require.NoError(t, err)
+
+	rs := newReplicaSet(nil, []*sql.DB{down}, 50*time.Millisecond)
+	rs.start()
+	require.False(t, rs.policy.isHealthy(down))
+	require.NoError(t, rs.close())
+}
@@ -0,0 +1,129 @@
+package orm
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// SQLiteMemory is the SQLite DBName of a private in-memory database.
+const SQLiteMemory = ":memory:"
+
+// sqliteDriver treats DBName as the database file path, or SQLiteMemory.
+// Host, port and credentials are ignored.
+type sqliteDriver struct{}
+
+func (sqliteDriver) sqlDriverName() string {
+	return sqlite.DriverName
+}
+
+func (sqliteDriver) buildDSN(_ *DBConfig, _ hostConfig, dbName string) string {
+	sep := "?"
+	if strings.Contains(dbName, "?") {
+		sep = "&"
+	}
+	return dbName + sep + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+func (sqliteDriver) utilDBName(*DBConfig) string {
+	return SQLiteMemory
+}
+
+func (sqliteDriver) dialector(conn *sql.DB) gorm.Dialector {
+	return &sqlite.Dialector{Conn: conn}
+}
+
+func (d sqliteDriver) replicaDialector(conn *sql.DB) gorm.Dialector {
+	return d.dialector(conn)
+}
+
+func (sqliteDriver) quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// createDB creates an empty database file, which SQLite accepts as an empty database.
+func (sqliteDriver) createDB(_ *gorm.DB, c *DBConfig) error {
+	if c.isSQLiteMemory() {
+		return nil
+	}
+	path := sqlitePath(c.DBName)
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// dropDB removes the database file and its journal files.
+func (sqliteDriver) dropDB(_ *gorm.DB, c *DBConfig) error {
+	if c.isSQLiteMemory() {
+		return nil
+	}
+	path := sqlitePath(c.DBName)
+	for _, name := range []string{path, path + "-journal", path + "-wal", path + "-shm"} {
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove %s failed: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (sqliteDriver) listTablesSQL() string {
+	return "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%';"
+}
+
+// truncateTables deletes the rows of every table in one transaction, since
+// SQLite has no TRUNCATE, and resets their AUTOINCREMENT sequences.
+// foreign_keys can only be changed outside a transaction.
+func (d sqliteDriver) truncateTables(conn *gorm.DB, tables []string) (err error) {
+	if err := conn.Exec("PRAGMA foreign_keys = OFF;").Error; err != nil {
+		return err
+	}
+	defer func() {
+		if resetErr := conn.Exec("PRAGMA foreign_keys = ON;").Error; err == nil {
+			err = resetErr
+		}
+	}()
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		for _, table := range tables {
+			if err := tx.Exec(fmt.Sprintf("DELETE FROM %s;", d.quoteIdentifier(table))).Error; err != nil {
+				return fmt.Errorf("delete from table %s failed: %w", table, err)
+			}
+		}
+		var hasSequence int64
+		err := tx.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_sequence';").
+			Scan(&hasSequence).Error
+		if err != nil || hasSequence == 0 {
+			return err
+		}
+		return tx.Exec("DELETE FROM sqlite_sequence WHERE name IN ?;", tables).Error
+	})
+}
+
+// sqlitePath strips the file: prefix and query of a SQLite DBName.
+func sqlitePath(dbName string) string {
+	dbName = strings.TrimPrefix(dbName, "file:")
+	if i := strings.Index(dbName, "?"); i >= 0 {
+		dbName = dbName[:i]
+	}
+	return dbName
+}
+
+// isSQLiteMemory reports whether the config is an in-memory SQLite database.
+func (c *DBConfig) isSQLiteMemory() bool {
+	return c.Driver == DriverSQLite &&
+		(c.DBName == SQLiteMemory || strings.Contains(c.DBName, "mode=memory"))
+}
@@ -0,0 +1,84 @@
+// Package principal carries the authenticated principal of a request in its
+// context. Authentication middleware stores it, and the layers below read it,
+// e.g. to audit who changed a row.
+package principal
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+type principalKey struct{}
+
+// Option is principal option.
+type Option func(*options)
+
+type options struct {
+	claims func(ctx context.Context) (string, bool)
+	header string
+}
+
+// WithClaims sets the function returning the principal ID from the claims
+// an authentication middleware earlier in the chain stored in the context,
+// e.g. the subject of a JWT.
+func WithClaims(claims func(ctx context.Context) (string, bool)) Option {
+	return func(o *options) {
+		o.claims = claims
+	}
+}
+
+// WithHeader trusts the request header key to carry the principal ID. Only
+// use it behind a gateway that authenticates callers and sets the header,
+// as clients could set it otherwise.
+func WithHeader(key string) Option {
+	return func(o *options) {
+		o.header = key
+	}
+}
+
+// Server returns a middleware that stores the principal of the request in
+// the context. The principal is taken from the claims, or from the trusted
+// header when there are none. Without either the request has no principal.
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			if id, ok := o.principal(ctx); ok {
+				ctx = NewContext(ctx, id)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+func (o *options) principal(ctx context.Context) (string, bool) {
+	if o.claims != nil {
+		if id, ok := o.claims(ctx); ok && id != "" {
+			return id, true
+		}
+	}
+	if o.header != "" {
+		if tr, ok := transport.FromServerContext(ctx); ok {
+			if id := tr.RequestHeader().Get(o.header); id != "" {
+				return id, true
+			}
+		}
+	}
+	return "", false
+}
+
+// NewContext returns a new context that carries the principal ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, principalKey{}, id)
+}
+
+// FromContext returns the principal ID stored in ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(principalKey{}).(string)
+	return id, ok && id != ""
+}
@@ -0,0 +1,93 @@
+package principal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type testTransport struct {
+	reqHeader headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return "/test" }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.reqHeader }
+func (tr *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	require.False(t, ok)
+
+	_, ok = FromContext(NewContext(context.Background(), ""))
+	require.False(t, ok)
+
+	id, ok := FromContext(NewContext(context.Background(), "user-1"))
+	require.True(t, ok)
+	require.Equal(t, "user-1", id)
+}
+
+func TestServer(t *testing.T) {
+	claims := func(id string) Option {
+		return WithClaims(func(context.Context) (string, bool) { return id, id != "" })
+	}
+	tests := []struct {
+		name     string
+		opts     []Option
+		incoming string
+		expected string
+	}{
+		{name: "trusted header", opts: []Option{WithHeader("X-Principal-ID")}, incoming: "alice", expected: "alice"},
+		{name: "untrusted header", incoming: "alice"},
+		{name: "missing header", opts: []Option{WithHeader("X-Principal-ID")}},
+		{name: "claims", opts: []Option{claims("bob")}, expected: "bob"},
+		{name: "claims before header", opts: []Option{claims("bob"), WithHeader("X-Principal-ID")}, incoming: "alice", expected: "bob"},
+		{name: "header without claims", opts: []Option{claims(""), WithHeader("X-Principal-ID")}, incoming: "alice", expected: "alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &testTransport{reqHeader: headerCarrier{}}
+			if tt.incoming != "" {
+				tr.reqHeader.Set("X-Principal-ID", tt.incoming)
+			}
+			ctx := transport.NewServerContext(context.Background(), tr)
+
+			var got string
+			h := Server(tt.opts...)(func(ctx context.Context, _ any) (any, error) {
+				got, _ = FromContext(ctx)
+				return nil, nil
+			})
+			_, err := h(ctx, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestServer_NoTransport(t *testing.T) {
+	h := Server(WithHeader("X-Principal-ID"))(func(ctx context.Context, _ any) (any, error) {
+		_, ok := FromContext(ctx)
+		return ok, nil
+	})
+	reply, err := h(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, false, reply)
+}
@@ -0,0 +1,308 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Op is a comparison operator of a filter.
+type Op string
+
+// Comparison operators of AIP-160.
+const (
+	OpEq  Op = "="
+	OpNe  Op = "!="
+	OpLt  Op = "<"
+	OpLe  Op = "<="
+	OpGt  Op = ">"
+	OpGe  Op = ">="
+	OpHas Op = ":"
+)
+
+// Expr is a parsed filter expression: And, Or, Not or Compare.
+type Expr interface {
+	expr()
+}
+
+// And matches when all of its expressions match.
+type And []Expr
+
+// Or matches when any of its expressions matches.
+type Or []Expr
+
+// Not matches when its expression does not match.
+type Not struct {
+	Expr Expr
+}
+
+// Compare compares a field with a value converted to the field's Kind.
+// A string compared with = or != may contain * wildcards, in which case
+// Wildcard is set; ":" on a string field matches a substring.
+type Compare struct {
+	Field    string
+	Op       Op
+	Value    any
+	Wildcard bool
+}
+
+func (And) expr()     {}
+func (Or) expr()      {}
+func (Not) expr()     {}
+func (Compare) expr() {}
+
+// ParseFilter parses an AIP-160 filter over the fields of schema. It supports
+// comparisons, AND (also implied by juxtaposition), OR, NOT or "-", and
+// parentheses. It returns nil for an empty filter.
+func ParseFilter(schema Schema, filter string) (Expr, error) {
+	tokens, err := lexFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &filterParser{schema: schema, tokens: tokens}
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q in filter", t.text)
+	}
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func lexFilter(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")"})
+			i++
+		case r == '"' || r == '\'':
+			text, n, err := lexString(runes[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text})
+			i += n
+		case strings.ContainsRune("=!<>:", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' && r != ':' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected \"!\" in filter")
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op})
+			i += len(op)
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes, start, i) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+// isWordRune reports whether runes[i] continues the word starting at start.
+// A ":" between digits belongs to the word, as in a timestamp; elsewhere it
+// is the has operator.
+func isWordRune(runes []rune, start, i int) bool {
+	r := runes[i]
+	if unicode.IsSpace(r) || strings.ContainsRune(`()"'=!<>`, r) {
+		return false
+	}
+	if r != ':' {
+		return true
+	}
+	return i > start && i+1 < len(runes) && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1])
+}
+
+// lexString reads a quoted string starting at runes[0], returning its
+// unescaped text and the number of runes read.
+func lexString(runes []rune) (string, int, error) {
+	quote := runes[0]
+	var b strings.Builder
+	for i := 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 == len(runes) {
+				return "", 0, fmt.Errorf("unterminated string in filter")
+			}
+			i++
+			b.WriteRune(runes[i])
+		case quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string in filter")
+}
+
+type filterParser struct {
+	schema Schema
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: tokenEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenWord && t.text == keyword
+}
+
+// parseAnd parses terms joined by AND or juxtaposition, which bind looser
+// than OR in AIP-160: "a AND b OR c" means "a AND (b OR c)".
+func (p *filterParser) parseAnd() (Expr, error) {
+	var terms And
+	for {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, e)
+		if p.isKeyword("AND") {
+			p.next()
+			continue
+		}
+		if t := p.peek(); t.kind == tokenEOF || t.kind == tokenRParen {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *filterParser) parseOr() (Expr, error) {
+	var terms Or
+	for {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, e)
+		if !p.isKeyword("OR") {
+			break
+		}
+		p.next()
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *filterParser) parseUnary() (Expr, error) {
+	if p.isKeyword("NOT") {
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: e}, nil
+	}
+	if t := p.peek(); t.kind == tokenWord && strings.HasPrefix(t.text, "-") && len(t.text) > 1 {
+		p.tokens[p.pos].text = t.text[1:]
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: e}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenRParen {
+			return nil, fmt.Errorf("missing \")\" in filter")
+		}
+		return e, nil
+	case tokenWord:
+		return p.parseCompare(t.text)
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of filter")
+	default:
+		return nil, fmt.Errorf("unexpected %q in filter", t.text)
+	}
+}
+
+func (p *filterParser) parseCompare(field string) (Expr, error) {
+	kind, ok := p.schema.Fields[field]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %q", field)
+	}
+	opToken := p.next()
+	if opToken.kind != tokenOp {
+		return nil, fmt.Errorf("expected an operator after %q in filter", field)
+	}
+	valueToken := p.next()
+	if valueToken.kind != tokenWord && valueToken.kind != tokenString {
+		return nil, fmt.Errorf("expected a value after %q in filter", field+" "+opToken.text)
+	}
+
+	c := Compare{Field: field, Op: Op(opToken.text)}
+	switch {
+	case kind == String && c.Op == OpHas:
+	case kind == String && (c.Op == OpEq || c.Op == OpNe):
+		c.Wildcard = strings.Contains(valueToken.text, "*")
+	case c.Op == OpHas:
+		return nil, fmt.Errorf("operator \":\" is not supported on field %q", field)
+	case kind == Bool && c.Op != OpEq && c.Op != OpNe:
+		return nil, fmt.Errorf("operator %q is not supported on field %q", c.Op, field)
+	}
+	v, err := kind.parse(valueToken.text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for filter field %q: %w", field, err)
+	}
+	c.Value = v
+	return c, nil
+}
@@ -0,0 +1,122 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = Schema{
+	Fields: map[string]Kind{
+		"id":          Int,
+		"hello":       String,
+		"active":      Bool,
+		"create_time": Time,
+	},
+	Key: "id",
+}
+
+func TestParseFilter(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter string
+		want   Expr
+	}{
+		{name: "empty", filter: "  ", want: nil},
+		{
+			name:   "compare string",
+			filter: `hello = "a b"`,
+			want:   Compare{Field: "hello", Op: OpEq, Value: "a b"},
+		},
+		{
+			name:   "compare int without spaces",
+			filter: "id>=10",
+			want:   Compare{Field: "id", Op: OpGe, Value: int64(10)},
+		},
+		{
+			name:   "compare unquoted time",
+			filter: "create_time < 2024-01-02T03:04:05Z",
+			want:   Compare{Field: "create_time", Op: OpLt, Value: created},
+		},
+		{
+			name:   "wildcard",
+			filter: `hello != "kra*"`,
+			want:   Compare{Field: "hello", Op: OpNe, Value: "kra*", Wildcard: true},
+		},
+		{
+			name:   "has",
+			filter: "hello:tos",
+			want:   Compare{Field: "hello", Op: OpHas, Value: "tos"},
+		},
+		{
+			name:   "implicit and",
+			filter: `hello = "a" active = true`,
+			want: And{
+				Compare{Field: "hello", Op: OpEq, Value: "a"},
+				Compare{Field: "active", Op: OpEq, Value: true},
+			},
+		},
+		{
+			name:   "or binds tighter than and",
+			filter: `id = 1 AND id = 2 OR id = 3`,
+			want: And{
+				Compare{Field: "id", Op: OpEq, Value: int64(1)},
+				Or{
+					Compare{Field: "id", Op: OpEq, Value: int64(2)},
+					Compare{Field: "id", Op: OpEq, Value: int64(3)},
+				},
+			},
+		},
+		{
+			name:   "parentheses and negation",
+			filter: `NOT (id = 1 OR id = 2) -hello = "x"`,
+			want: And{
+				Not{Expr: Or{
+					Compare{Field: "id", Op: OpEq, Value: int64(1)},
+					Compare{Field: "id", Op: OpEq, Value: int64(2)},
+				}},
+				Not{Expr: Compare{Field: "hello", Op: OpEq, Value: "x"}},
+			},
+		},
+		{
+			name:   "escaped quote",
+			filter: `hello = 'it\'s'`,
+			want:   Compare{Field: "hello", Op: OpEq, Value: "it's"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(testSchema, tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		wantErr string
+	}{
+		{name: "unknown field", filter: "name = 1", wantErr: `unknown filter field "name"`},
+		{name: "missing operator", filter: "id 1", wantErr: `expected an operator after "id"`},
+		{name: "missing value", filter: "id =", wantErr: `expected a value after "id ="`},
+		{name: "invalid int", filter: "id = abc", wantErr: `invalid value for filter field "id"`},
+		{name: "invalid time", filter: `create_time > "yesterday"`, wantErr: `invalid value for filter field "create_time"`},
+		{name: "has on int", filter: "id:1", wantErr: `operator ":" is not supported on field "id"`},
+		{name: "ordering bool", filter: "active > true", wantErr: `operator ">" is not supported on field "active"`},
+		{name: "unclosed parenthesis", filter: "(id = 1", wantErr: `missing ")"`},
+		{name: "stray parenthesis", filter: "id = 1)", wantErr: `unexpected ")"`},
+		{name: "unterminated string", filter: `hello = "abc`, wantErr: "unterminated string"},
+		{name: "dangling or", filter: "id = 1 OR", wantErr: "unexpected end of filter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilter(testSchema, tt.filter)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
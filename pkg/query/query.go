@@ -0,0 +1,162 @@
+// Package query parses list requests in the style of AIP-158 (pagination),
+// AIP-160 (filtering) and AIP-132 (ordering) into a Query that repositories
+// translate to their storage.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Page sizes of a Query.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// Kind is the type of a filterable or orderable field.
+type Kind int
+
+// Field kinds.
+const (
+	String Kind = iota
+	Int
+	Bool
+	Time
+)
+
+// parse converts a filter literal to a value of kind.
+func (k Kind) parse(s string) (any, error) {
+	switch k {
+	case Int:
+		return strconv.ParseInt(s, 10, 64)
+	case Bool:
+		return strconv.ParseBool(s)
+	case Time:
+		return time.Parse(time.RFC3339Nano, s)
+	default:
+		return s, nil
+	}
+}
+
+// Schema lists the fields a list request may filter and order by.
+type Schema struct {
+	Fields map[string]Kind
+	// Key is a unique field appended to every ordering, which makes the
+	// order total so that keyset pagination neither skips nor repeats rows.
+	Key string
+}
+
+// OrderField is one field of an ordering.
+type OrderField struct {
+	Field string
+	Desc  bool
+}
+
+// Request is the pagination, filter and ordering of a list request.
+type Request struct {
+	PageSize  int32
+	PageToken string
+	Filter    string
+	OrderBy   string
+}
+
+// Query is a parsed Request.
+type Query struct {
+	Filter Expr
+	// OrderBy always ends with the schema's Key.
+	OrderBy  []OrderField
+	PageSize int
+	// After holds the OrderBy values of the last row of the previous page,
+	// and is nil for the first page.
+	After []any
+
+	checksum string
+}
+
+// Parse parses req over the fields of schema. A page size of 0 selects
+// DefaultPageSize and larger sizes than MaxPageSize are reduced to it.
+func Parse(schema Schema, req Request) (*Query, error) {
+	if req.PageSize < 0 {
+		return nil, fmt.Errorf("page_size must not be negative")
+	}
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize == 0:
+		pageSize = DefaultPageSize
+	case pageSize > MaxPageSize:
+		pageSize = MaxPageSize
+	}
+
+	filter, err := ParseFilter(schema, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	orderBy, err := ParseOrderBy(schema, req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{
+		Filter:   filter,
+		OrderBy:  orderBy,
+		PageSize: pageSize,
+		checksum: checksum(req.Filter, req.OrderBy),
+	}
+	if req.PageToken != "" {
+		if q.After, err = decodePageToken(schema, orderBy, q.checksum, req.PageToken); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// NextPageToken returns the token of the page after the row whose OrderBy
+// values are after, or "" when after is nil, meaning there is no next page.
+func (q *Query) NextPageToken(after []any) (string, error) {
+	if after == nil {
+		return "", nil
+	}
+	return encodePageToken(q.checksum, after)
+}
+
+// ParseOrderBy parses an AIP-132 order_by such as "create_time desc, hello"
+// over the fields of schema, and appends the schema's Key unless present.
+func ParseOrderBy(schema Schema, orderBy string) ([]OrderField, error) {
+	var (
+		fields []OrderField
+		seen   = make(map[string]bool)
+	)
+	if strings.TrimSpace(orderBy) != "" {
+		for _, part := range strings.Split(orderBy, ",") {
+			words := strings.Fields(part)
+			if len(words) == 0 || len(words) > 2 {
+				return nil, fmt.Errorf("invalid order_by %q", orderBy)
+			}
+			f := OrderField{Field: words[0]}
+			if len(words) == 2 {
+				switch words[1] {
+				case "asc":
+				case "desc":
+					f.Desc = true
+				default:
+					return nil, fmt.Errorf("invalid order_by direction %q", words[1])
+				}
+			}
+			if _, ok := schema.Fields[f.Field]; !ok {
+				return nil, fmt.Errorf("unknown order_by field %q", f.Field)
+			}
+			if seen[f.Field] {
+				return nil, fmt.Errorf("duplicate order_by field %q", f.Field)
+			}
+			seen[f.Field] = true
+			fields = append(fields, f)
+		}
+	}
+	if !seen[schema.Key] {
+		fields = append(fields, OrderField{Field: schema.Key})
+	}
+	return fields, nil
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_PageSize(t *testing.T) {
+	tests := []struct {
+		pageSize int32
+		want     int
+	}{
+		{pageSize: 0, want: DefaultPageSize},
+		{pageSize: 10, want: 10},
+		{pageSize: MaxPageSize + 1, want: MaxPageSize},
+	}
+	for _, tt := range tests {
+		q, err := Parse(testSchema, Request{PageSize: tt.pageSize})
+		require.NoError(t, err)
+		require.Equal(t, tt.want, q.PageSize)
+	}
+
+	_, err := Parse(testSchema, Request{PageSize: -1})
+	require.ErrorContains(t, err, "page_size must not be negative")
+}
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    []OrderField
+		wantErr string
+	}{
+		{orderBy: "", want: []OrderField{{Field: "id"}}},
+		{orderBy: "id desc", want: []OrderField{{Field: "id", Desc: true}}},
+		{
+			orderBy: "create_time desc,  hello",
+			want:    []OrderField{{Field: "create_time", Desc: true}, {Field: "hello"}, {Field: "id"}},
+		},
+		{orderBy: "name", wantErr: `unknown order_by field "name"`},
+		{orderBy: "hello up", wantErr: `invalid order_by direction "up"`},
+		{orderBy: "hello, hello desc", wantErr: `duplicate order_by field "hello"`},
+		{orderBy: "hello,", wantErr: "invalid order_by"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			got, err := ParseOrderBy(testSchema, tt.orderBy)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	req := Request{Filter: `hello = "a"`, OrderBy: "create_time desc, hello, active"}
+	q, err := Parse(testSchema, req)
+	require.NoError(t, err)
+	require.Nil(t, q.After)
+
+	token, err := q.NextPageToken(nil)
+	require.NoError(t, err)
+	require.Empty(t, token)
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC)
+	after := []any{created, "a", true, int64(1) << 60}
+	token, err = q.NextPageToken(after)
+	require.NoError(t, err)
+
+	req.PageToken = token
+	next, err := Parse(testSchema, req)
+	require.NoError(t, err)
+	require.Len(t, next.After, 4)
+	require.True(t, created.Equal(next.After[0].(time.Time)))
+	require.Equal(t, after[1:], next.After[1:])
+
+	// A token is only valid with the filter and order_by it was issued for.
+	changed := req
+	changed.Filter = `hello = "b"`
+	_, err = Parse(testSchema, changed)
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+
+	for _, token := range []string{"%%%", "bm90IGpzb24"} {
+		req.PageToken = token
+		_, err = Parse(testSchema, req)
+		require.ErrorIs(t, err, ErrInvalidPageToken)
+	}
+}
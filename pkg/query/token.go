@@ -0,0 +1,83 @@
+package query
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPageToken is returned for a page token that is malformed or was
+// issued for a different filter or order_by.
+var ErrInvalidPageToken = errors.New("invalid page_token")
+
+// pageToken is the content of an opaque page token.
+type pageToken struct {
+	// Checksum ties the token to the filter and order_by it was issued for.
+	Checksum string `json:"c"`
+	After    []any  `json:"a"`
+}
+
+func checksum(filter, orderBy string) string {
+	sum := sha256.Sum256([]byte(filter + "\x00" + orderBy))
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodePageToken(checksum string, after []any) (string, error) {
+	b, err := json.Marshal(pageToken{Checksum: checksum, After: after})
+	if err != nil {
+		return "", fmt.Errorf("encode page token failed: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodePageToken decodes token and converts its values to the kinds of the
+// orderBy fields, which JSON does not preserve.
+func decodePageToken(schema Schema, orderBy []OrderField, checksum, token string) ([]any, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var t pageToken
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&t); err != nil || t.Checksum != checksum || len(t.After) != len(orderBy) {
+		return nil, ErrInvalidPageToken
+	}
+
+	after := make([]any, len(orderBy))
+	for i, f := range orderBy {
+		v, err := tokenValue(schema.Fields[f.Field], t.After[i])
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		after[i] = v
+	}
+	return after, nil
+}
+
+func tokenValue(kind Kind, v any) (any, error) {
+	switch kind {
+	case Int:
+		if n, ok := v.(json.Number); ok {
+			return n.Int64()
+		}
+	case Bool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case Time:
+		if s, ok := v.(string); ok {
+			return time.Parse(time.RFC3339Nano, s)
+		}
+	default:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected page token value %v", v)
+}